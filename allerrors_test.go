@@ -0,0 +1,56 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAllErrors(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("abc\nabcdefgh1\n")
+	prompt := prompter.New(writer, reader)
+	pass, err := prompt.AllErrors(true).
+		Is(prompter.MinLength(8)).
+		Is(prompter.MatchString(`[0-9]`, "must contain a digit")).
+		Ask(ctx, "Password?")
+	is.NoErr(err)
+	is.Equal(pass, "abcdefgh1")
+	diff.TestString(t, writer.String(), "Password? must be at least 8 characters, got 3\nmust contain a digit\nPassword? ")
+}
+
+func TestAllErrorsOnce(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	tooShort := errors.New("too short")
+	noDigit := errors.New("no digit")
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("abc\n"))
+	_, err := prompt.AllErrors(true).
+		Is(func(string) error { return tooShort }).
+		Is(func(string) error { return noDigit }).
+		Once().
+		Ask(ctx, "Password?")
+	is.True(errors.Is(err, prompter.ErrValidation))
+	is.True(errors.Is(err, tooShort))
+	is.True(errors.Is(err, noDigit))
+}
+
+func TestAllErrorsOff(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("abc\nabcdefgh1\n")
+	prompt := prompter.New(writer, reader)
+	_, err := prompt.Is(prompter.MinLength(8)).
+		Is(prompter.MatchString(`[0-9]`, "must contain a digit")).
+		Ask(ctx, "Password?")
+	is.NoErr(err)
+	diff.TestString(t, writer.String(), "Password? must be at least 8 characters, got 3\nPassword? ")
+}
@@ -0,0 +1,62 @@
+package prompter
+
+import "context"
+
+// Answer is an answer to a question, along with how it was given
+type Answer struct {
+	// Value is the answer
+	Value string
+	// UsedDefault is true when nothing was entered and the default was used,
+	// rather than the default being typed in
+	UsedDefault bool
+	// Attempts is the number of times the question was asked
+	Attempts int
+}
+
+// AskResult asks a question and returns the answer along with how it was given
+func (p *Prompt) AskResult(ctx context.Context, prompt string) (Answer, error) {
+	q := newQuestion(p)
+	return q.AskResult(ctx, prompt)
+}
+
+// AskResult asks a question and returns the answer along with how it was
+// given, which is useful for audit logs
+func (q *Question) AskResult(ctx context.Context, prompt string) (Answer, error) {
+	value, err := q.ask(ctx, prompt, q.hidden, q.readInput)
+	if err != nil {
+		return Answer{}, err
+	}
+	return Answer{
+		Value:       value,
+		UsedDefault: q.usedDefault,
+		Attempts:    q.attempts,
+	}, nil
+}
+
+// OnAnswer calls fn after each question is answered, which is useful for
+// building a transcript. The password flag is set for passwords and hidden
+// answers, so they can be left out of logs.
+func (p *Prompt) OnAnswer(fn func(prompt, value string, password bool)) *Prompt {
+	p.onAnswer = fn
+	return p
+}
+
+// accept echoes the answer if needed, calls the answer callback and returns
+// the answer
+func (q *Question) accept(prompt, answer string, password bool) (string, error) {
+	p := q.prompter
+	if q.silent {
+		return answer, nil
+	} else if err := p.echo(answer, password); err != nil {
+		return "", err
+	}
+	p.answered(prompt, answer, password)
+	return answer, nil
+}
+
+// answered calls the answer callback
+func (p *Prompt) answered(prompt, answer string, password bool) {
+	if p.onAnswer != nil {
+		p.onAnswer(prompt, answer, password)
+	}
+}
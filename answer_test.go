@@ -0,0 +1,65 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskResult(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\nmain\nA\nAlice\n")
+	prompt := prompter.New(io.Discard, reader)
+	answer, err := prompt.Default("main").AskResult(ctx, "Branch?")
+	is.NoErr(err)
+	is.Equal(answer, prompter.Answer{Value: "main", UsedDefault: true, Attempts: 1})
+	answer, err = prompt.Default("main").AskResult(ctx, "Branch?")
+	is.NoErr(err)
+	is.Equal(answer, prompter.Answer{Value: "main", UsedDefault: false, Attempts: 1})
+	answer, err = prompt.Is(prompter.MinLength(2)).AskResult(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(answer, prompter.Answer{Value: "Alice", UsedDefault: false, Attempts: 2})
+}
+
+func TestAskResultDefaultFunc(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(io.Discard, reader)
+	answer, err := prompt.DefaultFunc(func() (string, error) {
+		return "main", nil
+	}).AskResult(ctx, "Branch?")
+	is.NoErr(err)
+	is.Equal(answer, prompter.Answer{Value: "main", UsedDefault: true, Attempts: 1})
+}
+
+func TestOnAnswer(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Al\nAlice\nsecret\n\n")
+	type answer struct {
+		prompt   string
+		value    string
+		password bool
+	}
+	var answers []answer
+	prompt := prompter.New(io.Discard, reader).OnAnswer(func(prompt, value string, password bool) {
+		answers = append(answers, answer{prompt, value, password})
+	})
+	_, err := prompt.Is(prompter.MinLength(3)).Ask(ctx, "Name?")
+	is.NoErr(err)
+	_, err = prompt.Password(ctx, "Password?")
+	is.NoErr(err)
+	_, err = prompt.ConfirmDefault(ctx, "Continue?", true)
+	is.NoErr(err)
+	is.Equal(answers, []answer{
+		{"Name?", "Alice", false},
+		{"Password?", "secret", true},
+		{"Continue? [Y/n]", "yes", false},
+	})
+}
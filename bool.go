@@ -0,0 +1,39 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// AskBool asks for a true or false value and returns it
+func (p *Prompt) AskBool(ctx context.Context, prompt string) (bool, error) {
+	q := newQuestion(p)
+	return q.AskBool(ctx, prompt)
+}
+
+// AskBool asks for a true or false value and returns it. Along with the values
+// accepted by strconv.ParseBool, the input may be on, off, y, yes, n or no in
+// any case. Validators run against the input before it's parsed. false is
+// returned when the question is optional and nothing was entered.
+func (q *Question) AskBool(ctx context.Context, prompt string) (bool, error) {
+	return askParse(ctx, q, prompt, func(s string) (bool, error) {
+		b, err := parseBool(s)
+		if err != nil {
+			return false, errors.New("please enter true or false")
+		}
+		return b, nil
+	})
+}
+
+func parseBool(s string) (bool, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "on", "y", "yes":
+		return true, nil
+	case "off", "n", "no":
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
@@ -0,0 +1,66 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskBool(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("maybe\nOn\n0\nYES\nf\n")
+	prompt := prompter.New(writer, reader)
+	debug, err := prompt.AskBool(ctx, "Debug?")
+	is.NoErr(err)
+	is.Equal(debug, true)
+	diff.TestString(t, writer.String(), "Debug? please enter true or false\nDebug? ")
+	debug, err = prompt.AskBool(ctx, "Debug?")
+	is.NoErr(err)
+	is.Equal(debug, false)
+	debug, err = prompt.AskBool(ctx, "Debug?")
+	is.NoErr(err)
+	is.Equal(debug, true)
+	debug, err = prompt.AskBool(ctx, "Debug?")
+	is.NoErr(err)
+	is.Equal(debug, false)
+}
+
+func TestAskBoolDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(writer, reader)
+	debug, err := prompt.Default("false").AskBool(ctx, "Debug?")
+	is.NoErr(err)
+	is.Equal(debug, false)
+	diff.TestString(t, writer.String(), "Debug? [false] ")
+}
+
+func TestAskBoolOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	debug, err := prompt.Optional(true).AskBool(ctx, "Debug?")
+	is.NoErr(err)
+	is.Equal(debug, false)
+}
+
+func TestAskBoolErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	debug, err := prompt.AskBool(ctx, "Debug?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(debug, false)
+}
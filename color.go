@@ -0,0 +1,32 @@
+package prompter
+
+import "os"
+
+// Colors sets the functions used to color the prompt and error messages, such
+// as wrapping them in ANSI escape codes. Colors are only used when the writer
+// is a terminal and the NO_COLOR environment variable isn't set. Either
+// function may be nil to leave that output uncolored.
+func (p *Prompt) Colors(promptColor, errorColor func(string) string) *Prompt {
+	p.theme.PromptColor = promptColor
+	p.theme.ErrorColor = errorColor
+	return p
+}
+
+// colorful is true when colors should be written
+func (p *Prompt) colorful() bool {
+	return p.writesTerminal() && os.Getenv("NO_COLOR") == ""
+}
+
+func (p *Prompt) colorPrompt(s string) string {
+	if p.theme.PromptColor == nil || !p.colorful() {
+		return s
+	}
+	return p.theme.PromptColor(s)
+}
+
+func (p *Prompt) colorError(s string) string {
+	if p.theme.ErrorColor == nil || !p.colorful() {
+		return s
+	}
+	return p.theme.ErrorColor(s)
+}
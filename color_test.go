@@ -0,0 +1,31 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func red(s string) string {
+	return "\033[31m" + s + "\033[0m"
+}
+
+func bold(s string) string {
+	return "\033[1m" + s + "\033[0m"
+}
+
+func TestColorsDisabledWithoutTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Am\nAmy\n")
+	prompt := prompter.New(writer, reader).Colors(bold, red)
+	name, err := prompt.Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Amy")
+	diff.TestString(t, writer.String(), "What is your name? must be at least 3 characters, got 2\nWhat is your name? ")
+}
@@ -0,0 +1,86 @@
+package prompter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Complete sets the function that completes the input when tab is pressed
+func (p *Prompt) Complete(fn func(prefix string) []string) *Question {
+	q := newQuestion(p)
+	q.complete = fn
+	return q
+}
+
+// Complete sets the function that completes the input when tab is pressed.
+// The function is called with the input before the cursor and returns the
+// possible completions for it. Tab completes the input up to the longest
+// prefix the completions share, then cycles through them. This turns on the
+// line editor when reading from a terminal, otherwise it does nothing.
+func (q *Question) Complete(fn func(prefix string) []string) *Question {
+	q.complete = fn
+	return q
+}
+
+// FileComplete completes file paths relative to the root directory. Absolute
+// paths are completed as-is. Directories end with a path separator and hidden
+// files are only completed once the prefix starts with a dot.
+func FileComplete(root string) func(prefix string) []string {
+	return func(prefix string) []string {
+		dir, base := filepath.Split(prefix)
+		path := dir
+		if !filepath.IsAbs(dir) {
+			path = filepath.Join(root, dir)
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil
+		}
+		var completions []string
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, base) {
+				continue
+			} else if strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+				continue
+			}
+			if entry.IsDir() {
+				name += string(filepath.Separator)
+			}
+			completions = append(completions, dir+name)
+		}
+		return completions
+	}
+}
+
+// Suggestions shows suggested values after the prompt
+func (p *Prompt) Suggestions(values ...string) *Question {
+	q := newQuestion(p)
+	q.suggestions = values
+	return q
+}
+
+// Suggestions shows suggested values in parentheses after the prompt, before
+// the default. When the line editor is used and there's no Complete function,
+// tab completes the suggestions.
+func (q *Question) Suggestions(values ...string) *Question {
+	q.suggestions = values
+	return q
+}
+
+// completer returns the function that completes the input, if any
+func (q *Question) completer() func(prefix string) []string {
+	if q.complete != nil || len(q.suggestions) == 0 {
+		return q.complete
+	}
+	return func(prefix string) []string {
+		var completions []string
+		for _, value := range q.suggestions {
+			if strings.HasPrefix(value, prefix) {
+				completions = append(completions, value)
+			}
+		}
+		return completions
+	}
+}
@@ -0,0 +1,46 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestFileComplete(t *testing.T) {
+	is := is.New(t)
+	root := t.TempDir()
+	is.NoErr(os.MkdirAll(filepath.Join(root, "cmd", "app"), 0755))
+	is.NoErr(os.WriteFile(filepath.Join(root, "go.mod"), nil, 0644))
+	is.NoErr(os.WriteFile(filepath.Join(root, "go.sum"), nil, 0644))
+	is.NoErr(os.WriteFile(filepath.Join(root, ".gitignore"), nil, 0644))
+	is.NoErr(os.WriteFile(filepath.Join(root, "cmd", "main.go"), nil, 0644))
+	complete := prompter.FileComplete(root)
+	is.Equal(complete("go"), []string{"go.mod", "go.sum"})
+	is.Equal(complete(""), []string{"cmd/", "go.mod", "go.sum"})
+	is.Equal(complete("."), []string{".gitignore"})
+	is.Equal(complete("cmd/"), []string{"cmd/app/", "cmd/main.go"})
+	is.Equal(complete("cmd/m"), []string{"cmd/main.go"})
+	is.Equal(complete("missing/"), nil)
+	is.Equal(complete(filepath.Join(root, "cmd")+"/a"), []string{filepath.Join(root, "cmd", "app") + "/"})
+}
+
+func TestSuggestions(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n\n")
+	prompt := prompter.New(writer, reader)
+	region, err := prompt.Suggestions("us-east-1", "eu-west-1").Default("us-east-1").Ask(ctx, "Region?")
+	is.NoErr(err)
+	is.Equal(region, "us-east-1")
+	region, err = prompt.Suggestions().Optional(true).Ask(ctx, "Region?")
+	is.NoErr(err)
+	is.Equal(region, "")
+	diff.TestString(t, writer.String(), "Region? (us-east-1, eu-west-1) [us-east-1] Region? ")
+}
@@ -0,0 +1,56 @@
+package prompter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestConfirmAcceptedWords(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	check := func(word string, want bool) {
+		t.Helper()
+		prompt := New(io.Discard, bytes.NewBufferString(word+"\n"))
+		ok, err := prompt.Once().Confirm(ctx, "Continue?")
+		is.NoErr(err)
+		is.Equal(ok, want)
+	}
+	for _, word := range yesWords {
+		check(word, true)
+		check(strings.ToUpper(word), true)
+	}
+	for _, word := range noWords {
+		check(word, false)
+		check(strings.ToUpper(word), false)
+	}
+}
+
+func TestConfirmKeepsValidators(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := New(io.Discard, bytes.NewBufferString("yes\nno\n2\n42\n"))
+	calls := 0
+	question := prompt.Is(func(s string) error {
+		calls++
+		return nil
+	})
+	ok, err := question.Confirm(ctx, "Continue?")
+	is.NoErr(err)
+	is.True(ok)
+	ok, err = question.Confirm(ctx, "Continue?")
+	is.NoErr(err)
+	is.True(!ok)
+	is.Equal(len(question.validators), 1)
+	// The other methods that add validators don't keep them either
+	_, err = question.Select(ctx, "Environment?", []string{"dev", "prod"})
+	is.NoErr(err)
+	_, err = question.AskInt(ctx, "Port?")
+	is.NoErr(err)
+	is.Equal(len(question.validators), 1)
+	is.Equal(calls, 4)
+}
@@ -0,0 +1,163 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Countdown asks a question that answers itself with the default once the
+// countdown runs out
+func (p *Prompt) Countdown(ctx context.Context, prompt string, d time.Duration) (string, error) {
+	q := newQuestion(p)
+	return q.Countdown(ctx, prompt, d)
+}
+
+// Countdown asks a question that answers itself with the default once the
+// countdown runs out, like "Deploying to prod in 5s". On a terminal, the
+// seconds left are shown after the prompt. Pressing any key stops the
+// countdown, then the answer is typed in as usual. When the input isn't a
+// terminal, this is the same as Timeout. Without a default, ErrTimeout is
+// returned when the countdown runs out.
+func (q *Question) Countdown(ctx context.Context, prompt string, d time.Duration) (string, error) {
+	if d <= 0 || q.hidden || !q.prompter.isTerminal() {
+		defer func(timeout time.Duration) { q.timeout = timeout }(q.timeout)
+		q.timeout = d
+		return q.Ask(ctx, prompt)
+	}
+	// Only the first attempt counts down, asking again after an invalid answer
+	// waits for the input
+	counting := true
+	return q.ask(ctx, prompt, false, func(ctx context.Context) (string, error) {
+		if !counting {
+			return q.readInput(ctx)
+		}
+		counting = false
+		return q.readCountdown(ctx, d)
+	})
+}
+
+// readCountdown waits for the first key press while counting down, then
+// reads the rest of the line
+func (q *Question) readCountdown(ctx context.Context, d time.Duration) (string, error) {
+	p := q.prompter
+	restore, err := p.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+	readCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	timer := time.AfterFunc(d, func() { cancel(ErrTimeout) })
+	defer timer.Stop()
+	c := startCountdown(p.writer, d)
+	defer c.stop()
+
+	input, err := q.readAsync(readCtx, func() (string, error) {
+		// Wait for a key without taking it, so it's part of the line
+		r, _, err := p.reader.ReadRune()
+		if err == nil {
+			p.reader.UnreadRune()
+		}
+		timer.Stop()
+		c.stop()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return q.scanLine()
+			}
+			return "", err
+		}
+		switch r {
+		case keyCtrlC:
+			p.reader.ReadRune()
+			if err := p.render("\r\n"); err != nil {
+				return "", err
+			}
+			return "", ErrInterrupted
+		case '\r', '\n':
+			p.reader.ReadRune()
+			return "", p.render("\r\n")
+		}
+		// Go back to reading lines. The key was read in raw mode, so the
+		// terminal didn't echo it.
+		p.restoreTerminal()
+		if !q.editing() && unicode.IsPrint(r) {
+			if err := p.render(string(r)); err != nil {
+				return "", err
+			}
+		}
+		return q.scanLine()
+	})
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(readCtx), ErrTimeout) {
+		restore()
+		c.stop()
+		// Move past the unanswered prompt
+		if err := p.render("\n"); err != nil {
+			return "", err
+		}
+		// An empty input falls back to the default
+		if q.hasDefault() {
+			return "", nil
+		}
+		return "", ErrTimeout
+	}
+	return input, err
+}
+
+// countdown shows the seconds left after the prompt
+type countdown struct {
+	mu       sync.Mutex
+	w        io.Writer
+	deadline time.Time
+	shown    string
+	timer    *time.Timer
+	stopped  bool
+}
+
+func startCountdown(w io.Writer, d time.Duration) *countdown {
+	c := &countdown{w: w, deadline: time.Now().Add(d)}
+	c.tick()
+	return c
+}
+
+// tick shows the seconds left and waits for the next second
+func (c *countdown) tick() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	left := time.Until(c.deadline)
+	seconds := int(math.Ceil(left.Seconds()))
+	if seconds <= 0 {
+		return
+	}
+	text := fmt.Sprintf("(%ds)", seconds)
+	if text != c.shown {
+		cursorBack(c.w, len(c.shown))
+		fmt.Fprint(c.w, text, "\x1b[K")
+		c.shown = text
+	}
+	// Tick again when the seconds left goes down
+	c.timer = time.AfterFunc(left-time.Duration(seconds-1)*time.Second, c.tick)
+}
+
+// stop the countdown and erase it. It's safe to call more than once.
+func (c *countdown) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	c.stopped = true
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	cursorBack(c.w, len(c.shown))
+	fmt.Fprint(c.w, "\x1b[K")
+}
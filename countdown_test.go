@@ -0,0 +1,39 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestCountdown(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	r, w := io.Pipe()
+	defer w.Close()
+	writer := new(bytes.Buffer)
+	prompt := prompter.New(writer, r)
+	env, err := prompt.Default("prod").Countdown(ctx, "Deploy to?", 10*time.Millisecond)
+	is.NoErr(err)
+	is.Equal(env, "prod")
+	diff.TestString(t, writer.String(), "Deploy to? [prod] \n")
+	// Without a default, the countdown times out
+	_, err = prompt.Countdown(ctx, "Deploy to?", 10*time.Millisecond)
+	is.True(errors.Is(err, prompter.ErrTimeout))
+}
+
+func TestCountdownAnswered(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.New(io.Discard, bytes.NewBufferString("staging\n"))
+	env, err := prompt.Default("prod").Countdown(ctx, "Deploy to?", time.Second)
+	is.NoErr(err)
+	is.Equal(env, "staging")
+}
@@ -0,0 +1,58 @@
+package prompter
+
+import "fmt"
+
+// DefaultFunc sets a default value that's computed when it's needed
+func (p *Prompt) DefaultFunc(fn func() (string, error)) *Question {
+	q := newQuestion(p)
+	return q.DefaultFunc(fn)
+}
+
+// DefaultFunc sets a default value that's only computed when nothing was
+// entered, which is useful when the default is expensive to find. It's
+// computed at most once each time the question is asked and isn't shown in
+// the prompt. An error from fn is returned from the question. A static
+// Default takes precedence.
+func (q *Question) DefaultFunc(fn func() (string, error)) *Question {
+	q.defaultFunc = fn
+	return q
+}
+
+// DefaultOnBlank uses the default when only whitespace is entered
+func (p *Prompt) DefaultOnBlank(blank bool) *Question {
+	q := newQuestion(p)
+	return q.DefaultOnBlank(blank)
+}
+
+// DefaultOnBlank uses the default when only whitespace is entered, like a
+// stray space before pressing enter, rather than taking the whitespace as the
+// answer. It only applies when there's a default. By default, only an empty
+// answer uses the default.
+func (q *Question) DefaultOnBlank(blank bool) *Question {
+	q.defaultOnBlank = blank
+	return q
+}
+
+// hasDefault is true when the question has a default, even if it hasn't
+// been computed yet
+func (q *Question) hasDefault() bool {
+	return q.defaultTo != "" || q.defaultFunc != nil
+}
+
+// defaultValue returns the default to use in place of an empty answer,
+// computing it if needed
+func (q *Question) defaultValue() (string, error) {
+	if q.defaultTo != "" || q.defaultFunc == nil {
+		q.usedDefault = q.defaultTo != ""
+		return q.defaultTo, nil
+	}
+	if q.computed == nil {
+		defaultTo, err := q.defaultFunc()
+		if err != nil {
+			return "", fmt.Errorf("prompter: unable to compute the default: %w", err)
+		}
+		q.computed = &defaultTo
+	}
+	q.usedDefault = *q.computed != ""
+	return *q.computed, nil
+}
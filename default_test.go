@@ -0,0 +1,105 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestDefaultFunc(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(writer, reader)
+	calls := 0
+	branch, err := prompt.DefaultFunc(func() (string, error) {
+		calls++
+		return "main", nil
+	}).Ask(ctx, "Branch?")
+	is.NoErr(err)
+	is.Equal(branch, "main")
+	is.Equal(calls, 1)
+	diff.TestString(t, writer.String(), "Branch? ")
+}
+
+func TestDefaultFuncNotNeeded(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("feature\n")
+	prompt := prompter.New(new(bytes.Buffer), reader)
+	branch, err := prompt.DefaultFunc(func() (string, error) {
+		t.Fatal("default shouldn't be computed")
+		return "", nil
+	}).Ask(ctx, "Branch?")
+	is.NoErr(err)
+	is.Equal(branch, "feature")
+}
+
+func TestDefaultFuncError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(new(bytes.Buffer), reader)
+	errNoRepo := errors.New("not a git repository")
+	branch, err := prompt.DefaultFunc(func() (string, error) {
+		return "", errNoRepo
+	}).Ask(ctx, "Branch?")
+	is.True(errors.Is(err, errNoRepo))
+	is.Equal(err.Error(), "prompter: unable to compute the default: not a git repository")
+	is.Equal(branch, "")
+}
+
+func TestDefaultFuncEOF(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(new(bytes.Buffer), reader)
+	calls := 0
+	port, err := prompt.DefaultFunc(func() (string, error) {
+		calls++
+		return "8080", nil
+	}).AskInt(ctx, "Port?")
+	is.NoErr(err)
+	is.Equal(port, 8080)
+	is.Equal(calls, 1)
+}
+
+func TestDefaultFuncEmpty(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nmain\n")
+	prompt := prompter.New(writer, reader)
+	calls := 0
+	branch, err := prompt.DefaultFunc(func() (string, error) {
+		calls++
+		return "", nil
+	}).Ask(ctx, "Branch?")
+	is.NoErr(err)
+	is.Equal(branch, "main")
+	is.Equal(calls, 1)
+}
+
+func TestDefaultOnBlank(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("  \t\n  \n  Bob \n")
+	prompt := prompter.New(new(bytes.Buffer), reader)
+	name, err := prompt.Default("Alice").DefaultOnBlank(true).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	// Without the option, whitespace is the answer
+	name, err = prompt.Default("Alice").Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "  ")
+	// Other answers are left alone
+	name, err = prompt.Default("Alice").DefaultOnBlank(true).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "  Bob ")
+}
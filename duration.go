@@ -0,0 +1,31 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// AskDuration asks for a duration like 1m30s and returns it
+func (p *Prompt) AskDuration(ctx context.Context, prompt string) (time.Duration, error) {
+	q := newQuestion(p)
+	return q.AskDuration(ctx, prompt)
+}
+
+// AskDuration asks for a duration like 1m30s and returns it. Durations are
+// parsed with time.ParseDuration. Validators run against the input before it's
+// parsed. 0 is returned when the question is optional and nothing was entered.
+func (q *Question) AskDuration(ctx context.Context, prompt string) (time.Duration, error) {
+	return askParse(ctx, q, prompt, func(s string) (time.Duration, error) {
+		d, err := parseDuration(s)
+		if err != nil {
+			return 0, errors.New("please enter a duration like 1m30s")
+		}
+		return d, nil
+	})
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	return time.ParseDuration(strings.TrimSpace(s))
+}
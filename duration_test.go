@@ -0,0 +1,68 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskDuration(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("10\n1m30s\n")
+	prompt := prompter.New(writer, reader)
+	interval, err := prompt.AskDuration(ctx, "Interval?")
+	is.NoErr(err)
+	is.Equal(interval, 90*time.Second)
+	diff.TestString(t, writer.String(), "Interval? please enter a duration like 1m30s\nInterval? ")
+}
+
+func TestAskDurationDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	interval, err := prompt.Default("5s").AskDuration(ctx, "Interval?")
+	is.NoErr(err)
+	is.Equal(interval, 5*time.Second)
+}
+
+func TestAskDurationOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	interval, err := prompt.Optional(true).AskDuration(ctx, "Interval?")
+	is.NoErr(err)
+	is.Equal(interval, time.Duration(0))
+}
+
+func TestAskDurationErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	interval, err := prompt.AskDuration(ctx, "Interval?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(interval, time.Duration(0))
+}
+
+func TestAskDurationRange(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("-5s\n2h\n30s\n")
+	prompt := prompter.New(writer, reader)
+	interval, err := prompt.Is(prompter.DurationRange(0, time.Hour)).AskDuration(ctx, "Interval?")
+	is.NoErr(err)
+	is.Equal(interval, 30*time.Second)
+	diff.TestString(t, writer.String(), "Interval? must be between 0s and 1h0m0s, got -5s\nInterval? must be between 0s and 1h0m0s, got 2h0m0s\nInterval? ")
+}
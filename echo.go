@@ -0,0 +1,25 @@
+package prompter
+
+// EchoAnswers writes each accepted answer after its prompt when the input
+// isn't a terminal, so transcripts of piped input are complete. Passwords are
+// written as a placeholder. Terminals already show what's typed, so nothing
+// extra is written to them.
+func (p *Prompt) EchoAnswers(enable bool) *Prompt {
+	p.echoAnswers = enable
+	return p
+}
+
+// echoing is true when answers should be written after their prompt
+func (p *Prompt) echoing() bool {
+	return p.echoAnswers && !p.isTerminal()
+}
+
+// echo writes the answer after its prompt if answers are echoed
+func (p *Prompt) echo(answer string, password bool) error {
+	if !p.echoing() {
+		return nil
+	} else if password && answer != "" {
+		return p.render("****\n")
+	}
+	return p.render(answer + "\n")
+}
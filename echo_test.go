@@ -0,0 +1,54 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestEchoAnswers(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Al\nAlice\nsecret\n\n")
+	prompt := prompter.New(writer, reader).EchoAnswers(true)
+	name, err := prompt.Is(prompter.MinLength(3)).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	pass, err := prompt.Password(ctx, "Password?")
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+	ok, err := prompt.ConfirmDefault(ctx, "Continue?", true)
+	is.NoErr(err)
+	is.True(ok)
+	diff.TestString(t, writer.String(), "Name? must be at least 3 characters, got 2\nName? Alice\nPassword? ****\nContinue? [Y/n] yes\n")
+}
+
+func TestEchoAnswersNewPassword(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("secret\nsecret2\nsecret\nsecret\n")
+	prompt := prompter.New(writer, reader).EchoAnswers(true)
+	pass, err := prompt.NewPassword(ctx, "New:", "Confirm:")
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+	diff.TestString(t, writer.String(), "New: ****\nConfirm: ****\npasswords do not match\nNew: ****\nConfirm: ****\n")
+}
+
+func TestEchoAnswersOff(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Alice\nsecret\n")
+	prompt := prompter.New(writer, reader)
+	_, err := prompt.Ask(ctx, "Name?")
+	is.NoErr(err)
+	_, err = prompt.Password(ctx, "Password?")
+	is.NoErr(err)
+	diff.TestString(t, writer.String(), "Name? Password? \n")
+}
@@ -0,0 +1,323 @@
+package prompter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Key codes read from a terminal in raw mode
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyBackspace = '\b'
+	keyTab       = '\t'
+	keyCtrlK     = 11
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// editor is a minimal line editor for terminals in raw mode
+type editor struct {
+	r        io.RuneReader
+	w        io.Writer
+	line     []rune
+	pos      int
+	complete func(prefix string) []string
+
+	// Completions being cycled through with tab
+	cycle      []string
+	cycleIndex int
+
+	// Previous answers recalled with the up and down arrows. The line being
+	// typed is kept in draft while looking through the history.
+	history      []string
+	historyIndex int
+	draft        []rune
+}
+
+// readLine reads a line, handling editing keys until enter is pressed. An
+// io.EOF error is returned if the input ends before anything is typed.
+func (e *editor) readLine() (string, error) {
+	for {
+		ch, _, err := e.r.ReadRune()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(e.line) > 0 {
+				return string(e.line), nil
+			}
+			return "", err
+		}
+		// Any key other than tab stops cycling through completions
+		if ch != keyTab {
+			e.cycle = nil
+		}
+		switch ch {
+		case '\r', '\n':
+			fmt.Fprint(e.w, "\r\n")
+			return string(e.line), nil
+		case keyCtrlC:
+			fmt.Fprint(e.w, "\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(e.line) == 0 {
+				return "", io.EOF
+			}
+			e.deleteForward()
+		case keyBackspace, keyDelete:
+			e.backspace()
+		case keyCtrlA:
+			e.move(0)
+		case keyCtrlE:
+			e.move(len(e.line))
+		case keyCtrlB:
+			e.move(e.pos - 1)
+		case keyCtrlF:
+			e.move(e.pos + 1)
+		case keyCtrlU:
+			e.deleteRange(0, e.pos)
+		case keyCtrlK:
+			e.deleteRange(e.pos, len(e.line))
+		case keyCtrlW:
+			e.deleteRange(e.wordStart(), e.pos)
+		case keyTab:
+			e.tab()
+		case keyEscape:
+			if err := e.escape(); err != nil {
+				return "", err
+			}
+		default:
+			// Ignore other control characters
+			if ch < ' ' {
+				continue
+			}
+			e.insert(ch)
+		}
+	}
+}
+
+// escape handles escape sequences like the arrow keys. Sequences look like
+// ESC [ D, ESC O H or ESC [ 3 ~.
+func (e *editor) escape() error {
+	ch, _, err := e.r.ReadRune()
+	if err != nil {
+		return err
+	} else if ch != '[' && ch != 'O' {
+		return nil
+	}
+	ch, _, err = e.r.ReadRune()
+	if err != nil {
+		return err
+	}
+	// Read the rest of numbered sequences, like ESC [ 3 ~
+	if ch >= '0' && ch <= '9' {
+		num := string(ch)
+		for {
+			if ch, _, err = e.r.ReadRune(); err != nil {
+				return err
+			} else if ch < '0' || ch > '9' {
+				break
+			}
+			num += string(ch)
+		}
+		// Ignore sequences with modifiers, like ESC [ 1 ; 5 C
+		if ch != '~' {
+			for ch == ';' || (ch >= '0' && ch <= '9') {
+				if ch, _, err = e.r.ReadRune(); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		switch num {
+		case "1", "7":
+			ch = 'H'
+		case "4", "8":
+			ch = 'F'
+		case "3":
+			e.deleteForward()
+			return nil
+		default:
+			return nil
+		}
+	}
+	switch ch {
+	case 'A':
+		e.recall(e.historyIndex - 1)
+	case 'B':
+		e.recall(e.historyIndex + 1)
+	case 'C':
+		e.move(e.pos + 1)
+	case 'D':
+		e.move(e.pos - 1)
+	case 'H':
+		e.move(0)
+	case 'F':
+		e.move(len(e.line))
+	}
+	return nil
+}
+
+// insert runes at the cursor
+func (e *editor) insert(runes ...rune) {
+	if len(runes) == 0 {
+		return
+	}
+	// Typing at the end of the line just needs the runes written out
+	if e.pos == len(e.line) {
+		e.line = append(e.line, runes...)
+		e.pos = len(e.line)
+		fmt.Fprint(e.w, string(runes))
+		return
+	}
+	prev := e.column()
+	tail := append(append([]rune{}, runes...), e.line[e.pos:]...)
+	e.line = append(e.line[:e.pos], tail...)
+	e.pos += len(runes)
+	e.redraw(prev)
+}
+
+// backspace deletes the rune before the cursor
+func (e *editor) backspace() {
+	e.deleteRange(e.pos-1, e.pos)
+}
+
+// tab completes the text before the cursor. If there are several
+// completions, the text is completed up to their longest common prefix.
+// After that, pressing tab again cycles through the completions.
+func (e *editor) tab() {
+	if e.complete == nil {
+		return
+	}
+	if len(e.cycle) > 0 {
+		e.cycleIndex = (e.cycleIndex + 1) % len(e.cycle)
+		e.replace([]rune(e.cycle[e.cycleIndex]))
+		return
+	}
+	prefix := string(e.line[:e.pos])
+	completions := e.complete(prefix)
+	switch len(completions) {
+	case 0:
+		return
+	case 1:
+		e.replace([]rune(completions[0]))
+		return
+	}
+	if common := commonPrefix(completions); len(common) > len(prefix) {
+		e.replace([]rune(common))
+		return
+	}
+	e.cycle = completions
+	e.cycleIndex = 0
+	e.replace([]rune(e.cycle[0]))
+}
+
+// replace the text before the cursor
+func (e *editor) replace(runes []rune) {
+	prev := e.column()
+	e.line = append(append([]rune{}, runes...), e.line[e.pos:]...)
+	e.pos = len(runes)
+	e.redraw(prev)
+}
+
+// commonPrefix finds the longest prefix shared by all the strings
+func commonPrefix(strs []string) string {
+	prefix := []rune(strs[0])
+	for _, s := range strs[1:] {
+		runes := []rune(s)
+		n := 0
+		for n < len(prefix) && n < len(runes) && prefix[n] == runes[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
+
+// recall replaces the line with an entry from the history. Recalling past the
+// newest entry brings back the line that was being typed.
+func (e *editor) recall(index int) {
+	if index < 0 || index > len(e.history) || index == e.historyIndex {
+		return
+	}
+	if e.historyIndex == len(e.history) {
+		e.draft = append([]rune{}, e.line...)
+	}
+	e.historyIndex = index
+	line := e.draft
+	if index < len(e.history) {
+		line = []rune(e.history[index])
+	}
+	prev := e.column()
+	e.line = append([]rune{}, line...)
+	e.pos = len(e.line)
+	e.redraw(prev)
+}
+
+// deleteForward deletes the rune under the cursor
+func (e *editor) deleteForward() {
+	e.deleteRange(e.pos, e.pos+1)
+}
+
+// deleteRange deletes the runes from start up to end and moves the cursor to
+// start
+func (e *editor) deleteRange(start, end int) {
+	if start < 0 || end > len(e.line) || start >= end {
+		return
+	}
+	prev := e.column()
+	e.line = append(e.line[:start], e.line[end:]...)
+	e.pos = start
+	e.redraw(prev)
+}
+
+// wordStart finds the start of the word before the cursor, skipping spaces
+func (e *editor) wordStart() int {
+	pos := e.pos
+	for pos > 0 && e.line[pos-1] == ' ' {
+		pos--
+	}
+	for pos > 0 && e.line[pos-1] != ' ' {
+		pos--
+	}
+	return pos
+}
+
+// move the cursor to a new position on the line
+func (e *editor) move(pos int) {
+	if pos < 0 || pos > len(e.line) || pos == e.pos {
+		return
+	}
+	if pos < e.pos {
+		cursorBack(e.w, runesWidth(e.line[pos:e.pos]))
+	} else if cols := runesWidth(e.line[e.pos:pos]); cols > 0 {
+		fmt.Fprintf(e.w, "\x1b[%dC", cols)
+	}
+	e.pos = pos
+}
+
+// column is the terminal column of the cursor, counting wide characters as
+// two columns
+func (e *editor) column() int {
+	return runesWidth(e.line[:e.pos])
+}
+
+// redraw the line after it's changed. The cursor was at column prev before
+// the change.
+func (e *editor) redraw(prev int) {
+	cursorBack(e.w, prev)
+	fmt.Fprint(e.w, string(e.line), "\x1b[K")
+	cursorBack(e.w, runesWidth(e.line[e.pos:]))
+}
+
+// cursorBack moves the cursor back a number of columns
+func cursorBack(w io.Writer, cols int) {
+	if cols > 0 {
+		fmt.Fprintf(w, "\x1b[%dD", cols)
+	}
+}
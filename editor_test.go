@@ -0,0 +1,172 @@
+package prompter
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+)
+
+func testEditor(input string) (*editor, *bytes.Buffer) {
+	writer := new(bytes.Buffer)
+	reader := bufio.NewReader(strings.NewReader(input))
+	return &editor{r: reader, w: writer}, writer
+}
+
+func TestEditorType(t *testing.T) {
+	is := is.New(t)
+	e, writer := testEditor("Mark\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "Mark")
+	diff.TestString(t, writer.String(), "Mark\r\n")
+}
+
+func TestEditorArrows(t *testing.T) {
+	is := is.New(t)
+	e, writer := testEditor("Mrk\x1b[D\x1b[Da\x1b[C\x1b[C\x1b[C!\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "Mark!")
+	diff.TestString(t, writer.String(), "Mrk\x1b[1D\x1b[1D\x1b[1DMark\x1b[K\x1b[2D\x1b[1C\x1b[1C!\r\n")
+}
+
+func TestEditorBackspace(t *testing.T) {
+	is := is.New(t)
+	e, writer := testEditor("Marx\x7fk\x1b[D\x1b[D\x7f\x7f\x7f\x7fM\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "Mrk")
+	diff.TestString(t, writer.String(), "Marx\x1b[4DMar\x1b[Kk\x1b[1D\x1b[1D\x1b[2DMrk\x1b[K\x1b[2D\x1b[1Drk\x1b[K\x1b[2DMrk\x1b[K\x1b[2D\r\n")
+}
+
+func TestEditorPrefill(t *testing.T) {
+	is := is.New(t)
+	e, writer := testEditor("\x7f2\r")
+	e.insert([]rune("21")...)
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "22")
+	diff.TestString(t, writer.String(), "21\x1b[2D2\x1b[K2\r\n")
+}
+
+func TestEditorEOF(t *testing.T) {
+	is := is.New(t)
+	e, _ := testEditor("\x04")
+	line, err := e.readLine()
+	is.True(errors.Is(err, io.EOF))
+	is.Equal(line, "")
+}
+
+func TestEditorHomeEnd(t *testing.T) {
+	is := is.New(t)
+	e, _ := testEditor("ark\x1b[HM\x1b[F!\x01<\x05>\x1b[1~[\x1b[4~]\x1bOH(\x1bOF)\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "([<Mark!>])")
+}
+
+func TestEditorDelete(t *testing.T) {
+	is := is.New(t)
+	e, _ := testEditor("Maxrk\x01\x06\x06\x1b[3~\x04\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "Mak")
+}
+
+func TestEditorKillLine(t *testing.T) {
+	is := is.New(t)
+	e, _ := testEditor("hello world\x02\x02\x02\x15ab\x0b\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "ab")
+}
+
+func TestEditorDeleteWord(t *testing.T) {
+	is := is.New(t)
+	e, _ := testEditor("git commit  --amend\x17\x17push\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "git push")
+}
+
+func TestEditorIgnoreModifiers(t *testing.T) {
+	is := is.New(t)
+	e, _ := testEditor("ab\x1b[1;5D\x1b[15~c\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "abc")
+}
+
+func TestEditorComplete(t *testing.T) {
+	is := is.New(t)
+	e, _ := testEditor("us\t\t\t1\r")
+	e.complete = func(prefix string) []string {
+		var completions []string
+		for _, region := range []string{"us-east-1", "us-east-2", "eu-west-1"} {
+			if strings.HasPrefix(region, prefix) {
+				completions = append(completions, region)
+			}
+		}
+		return completions
+	}
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "us-east-21")
+}
+
+func TestEditorCompleteSingle(t *testing.T) {
+	is := is.New(t)
+	e, writer := testEditor("e\t!\r")
+	e.complete = func(prefix string) []string {
+		return []string{"eu-west-1"}
+	}
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "eu-west-1!")
+	diff.TestString(t, writer.String(), "e\x1b[1Deu-west-1\x1b[K!\r\n")
+}
+
+func TestEditorHistory(t *testing.T) {
+	is := is.New(t)
+	e, _ := testEditor("st\x1b[A\x1b[A\x1b[A\x1b[B\r")
+	e.history = []string{"dev", "prod"}
+	e.historyIndex = len(e.history)
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "prod")
+}
+
+func TestEditorHistoryDraft(t *testing.T) {
+	is := is.New(t)
+	e, writer := testEditor("st\x1b[A\x1b[Bag\r")
+	e.history = []string{"dev"}
+	e.historyIndex = len(e.history)
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "stag")
+	diff.TestString(t, writer.String(), "st\x1b[2Ddev\x1b[K\x1b[3Dst\x1b[Kag\r\n")
+}
+
+func TestEditorInterrupted(t *testing.T) {
+	is := is.New(t)
+	e, writer := testEditor("ab\x03cd\r")
+	line, err := e.readLine()
+	is.True(errors.Is(err, ErrInterrupted))
+	is.Equal(line, "")
+	diff.TestString(t, writer.String(), "ab\r\n")
+}
+
+func TestEditorWide(t *testing.T) {
+	is := is.New(t)
+	e, writer := testEditor("日本\x1b[D\x1b[D語\x1b[C\x7f\r")
+	line, err := e.readLine()
+	is.NoErr(err)
+	is.Equal(line, "語本")
+	diff.TestString(t, writer.String(), "日本\x1b[2D\x1b[2D語日本\x1b[K\x1b[4D\x1b[2C\x1b[4D語本\x1b[K\x1b[2D\r\n")
+}
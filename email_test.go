@@ -0,0 +1,56 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// stubMX replaces the MX lookup for the duration of the test
+func stubMX(t *testing.T, lookup func(ctx context.Context, domain string) ([]*net.MX, error)) {
+	t.Helper()
+	original := lookupMX
+	lookupMX = lookup
+	t.Cleanup(func() { lookupMX = original })
+}
+
+func TestEmailDeliverable(t *testing.T) {
+	is := is.New(t)
+	var domains []string
+	stubMX(t, func(ctx context.Context, domain string) ([]*net.MX, error) {
+		domains = append(domains, domain)
+		switch domain {
+		case "example.com":
+			return []*net.MX{{Host: "mx.example.com.", Pref: 10}}, nil
+		case "null.example.com":
+			return []*net.MX{{Host: ".", Pref: 0}}, nil
+		case "flaky.example.com":
+			return nil, &net.DNSError{Err: "server misbehaving", Name: domain}
+		default:
+			return nil, &net.DNSError{Err: "no such host", Name: domain, IsNotFound: true}
+		}
+	})
+	validate := EmailDeliverable()
+	is.NoErr(validate("mark@example.com"))
+	is.NoErr(validate("Mark <mark@example.com>"))
+	is.Equal(validate("mark@exmaple.com").Error(), `"exmaple.com" doesn't accept email`)
+	is.Equal(validate("mark@null.example.com").Error(), `"null.example.com" doesn't accept email`)
+	is.Equal(validate("mark@flaky.example.com").Error(), `unable to check if "flaky.example.com" accepts email: lookup flaky.example.com: server misbehaving`)
+	is.Equal(validate("mark").Error(), `"mark" is not a valid email address`)
+	is.Equal(domains, []string{"example.com", "example.com", "exmaple.com", "null.example.com", "flaky.example.com"})
+}
+
+func TestEmailDeliverableContext(t *testing.T) {
+	is := is.New(t)
+	stubMX(t, func(ctx context.Context, domain string) ([]*net.MX, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := EmailDeliverableContext(ctx)("mark@example.com")
+	is.True(errors.Is(err, context.Canceled))
+}
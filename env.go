@@ -0,0 +1,39 @@
+package prompter
+
+import (
+	"fmt"
+	"os"
+)
+
+// FromEnv answers the question from the environment variable when it's set
+func (p *Prompt) FromEnv(key string) *Question {
+	q := newQuestion(p)
+	return q.FromEnv(key)
+}
+
+// FromEnv answers the question from the environment variable when it's set,
+// without showing the prompt. The value is trimmed, transformed and validated
+// like typed input. If it's invalid, the error is printed and the question is
+// asked as usual.
+func (q *Question) FromEnv(key string) *Question {
+	q.env = key
+	return q
+}
+
+// envAnswer returns the answer from the environment variable if it's set and
+// valid. An error is only returned when the invalid value can't be reported.
+func (q *Question) envAnswer() (string, bool, error) {
+	if q.env == "" {
+		return "", false, nil
+	}
+	value, err := q.preset(os.Getenv(q.env))
+	if err != nil {
+		if !q.quietErrors {
+			if err := q.prompter.printError(fmt.Errorf("invalid $%s: %w", q.env, err)); err != nil {
+				return "", false, err
+			}
+		}
+		return "", false, nil
+	}
+	return value, value != "", nil
+}
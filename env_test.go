@@ -0,0 +1,100 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestFromEnv(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	t.Setenv("APP_PORT", " 3000 ")
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(writer, reader)
+	port, err := prompt.FromEnv("APP_PORT").AskInt(ctx, "Port?")
+	is.NoErr(err)
+	is.Equal(port, 3000)
+	diff.TestString(t, writer.String(), "")
+}
+
+func TestFromEnvInvalid(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	t.Setenv("APP_PORT", "http")
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("8080\n")
+	prompt := prompter.New(writer, reader)
+	port, err := prompt.FromEnv("APP_PORT").AskInt(ctx, "Port?")
+	is.NoErr(err)
+	is.Equal(port, 8080)
+	diff.TestString(t, writer.String(), "invalid $APP_PORT: please enter a whole number\nPort? ")
+}
+
+func TestFromEnvUnset(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	t.Setenv("APP_ENV", "")
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("prod\n")
+	prompt := prompter.New(writer, reader)
+	env, err := prompt.FromEnv("APP_ENV").Default("dev").Ask(ctx, "Environment?")
+	is.NoErr(err)
+	is.Equal(env, "prod")
+	diff.TestString(t, writer.String(), "Environment? [dev] ")
+}
+
+func TestFromEnvConfirm(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	t.Setenv("APP_DEPLOY", "YES")
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString(""))
+	ok, err := prompt.FromEnv("APP_DEPLOY").Confirm(ctx, "Deploy?")
+	is.NoErr(err)
+	is.True(ok)
+}
+
+func TestAskIfEmpty(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("8080\n9090\n")
+	prompt := prompter.New(writer, reader)
+	// A value that's already set is used without asking
+	port, err := prompt.Is(prompter.IntRange(1, 65535)).AskIfEmpty(ctx, "3000", "Port?")
+	is.NoErr(err)
+	is.Equal(port, "3000")
+	diff.TestString(t, writer.String(), "")
+	// Empty values are asked for
+	port, err = prompt.AskIfEmpty(ctx, "  ", "Port?")
+	is.NoErr(err)
+	is.Equal(port, "8080")
+	// Invalid values are asked for again
+	writer.Reset()
+	port, err = prompt.Is(prompter.IntRange(1, 65535)).AskIfEmpty(ctx, "http", "Port?")
+	is.NoErr(err)
+	is.Equal(port, "9090")
+	diff.TestString(t, writer.String(), "invalid \"http\": please enter a whole number\nPort? ")
+}
+
+func TestAskIfEmptySecret(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString(""))
+	var passwords []bool
+	prompt.OnAnswer(func(prompt, value string, password bool) {
+		passwords = append(passwords, password)
+	})
+	_, err := prompt.Hidden(true).AskIfEmpty(ctx, "tok_123", "Token?")
+	is.NoErr(err)
+	_, err = prompt.Mask('*').AskIfEmpty(ctx, "tok_123", "Token?")
+	is.NoErr(err)
+	_, err = prompt.AskIfEmpty(ctx, "alice", "Name?")
+	is.NoErr(err)
+	is.Equal(passwords, []bool{true, true, false})
+}
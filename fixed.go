@@ -0,0 +1,59 @@
+package prompter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// AskFixed asks for exactly n characters, like a PIN
+func (p *Prompt) AskFixed(ctx context.Context, prompt string, n int) (string, error) {
+	q := newQuestion(p)
+	return q.AskFixed(ctx, prompt, n)
+}
+
+// AskFixed asks for exactly n characters, like a PIN or a one-time code. On a
+// terminal, the answer is submitted as soon as the nth character is typed,
+// without waiting for enter. The characters are echoed as the mask when
+// there's one and aren't echoed at all when the question is hidden. When the
+// input isn't a terminal, a line is read and it must be n characters long.
+func (q *Question) AskFixed(ctx context.Context, prompt string, n int) (string, error) {
+	defer q.withValidator(check(func(s string) error {
+		if length := utf8.RuneCountInString(s); s != "" && length != n {
+			return fmt.Errorf("must be exactly %d characters, got %d", n, length)
+		}
+		return nil
+	}))()
+	password := q.mask != 0 || q.hidden
+	return q.ask(ctx, prompt, password, func(ctx context.Context) (string, error) {
+		if !q.prompter.isTerminal() {
+			return q.readInput(ctx)
+		}
+		return q.readAsync(ctx, func() (string, error) {
+			return q.scanFixed(n)
+		})
+	})
+}
+
+// scanFixed puts the terminal into raw mode and reads up to n characters
+func (q *Question) scanFixed(n int) (string, error) {
+	p := q.prompter
+	restore, err := p.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+	w := p.writer
+	if q.hidden && q.mask == 0 {
+		w = io.Discard
+	}
+	input, err := readRunes(q.keyReader(), w, q.mask, q.reveal, n)
+	if err != nil {
+		return "", err
+	}
+	if err := p.render("\r\n"); err != nil {
+		return "", err
+	}
+	return input, nil
+}
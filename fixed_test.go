@@ -0,0 +1,23 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskFixed(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("12345\n1234\n")
+	prompt := prompter.New(writer, reader)
+	pin, err := prompt.AskFixed(ctx, "PIN?", 4)
+	is.NoErr(err)
+	is.Equal(pin, "1234")
+	diff.TestString(t, writer.String(), "PIN? must be exactly 4 characters, got 5\nPIN? ")
+}
@@ -0,0 +1,144 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Form asks a sequence of questions and collects the answers by key
+type Form struct {
+	prompter *Prompt
+	fields   []*formField
+	back     string
+	progress bool
+	// maxTotalAttempts is the number of failed attempts allowed across the
+	// form
+	maxTotalAttempts int
+}
+
+// errBack is returned by a question when the back token is entered
+var errBack = errors.New("prompter: go back")
+
+// ErrFormAttempts is returned when a form runs out of the failed attempts
+// allowed across all of its questions. It wraps the last validation error or
+// ErrRequired if the last input was empty.
+var ErrFormAttempts = fmt.Errorf("prompter: too many attempts across the form")
+
+// attemptBudget counts the failed attempts that are left, shared by the
+// questions in a form
+type attemptBudget struct {
+	left int
+}
+
+// spend a failed attempt, returning true once there are none left
+func (b *attemptBudget) spend() bool {
+	b.left--
+	return b.left <= 0
+}
+
+// formField is a question in the form
+type formField struct {
+	key      string
+	prompt   string
+	question *Question
+	password bool
+}
+
+// Form creates a form to ask a sequence of questions
+func (p *Prompt) Form() *Form {
+	return &Form{prompter: p}
+}
+
+// Ask adds a question to the form. The returned question can be configured
+// with the usual options, like Default, Optional and Is.
+func (f *Form) Ask(key, prompt string) *Question {
+	return f.add(key, prompt, false)
+}
+
+// Password adds a password to the form. The returned question can be
+// configured with the usual options, like Optional and Is.
+func (f *Form) Password(key, prompt string) *Question {
+	return f.add(key, prompt, true)
+}
+
+// Back sets the token that goes back to the previous question when it's
+// entered, like "<". The previous question is asked again with its answer as
+// the default. The token isn't checked by the validators. Going back is
+// disabled by default.
+func (f *Form) Back(token string) *Form {
+	f.back = token
+	return f
+}
+
+// Progress toggles showing the progress through the form before each prompt,
+// like "(2/5) What is your name?"
+func (f *Form) Progress(show bool) *Form {
+	f.progress = show
+	return f
+}
+
+// MaxTotalAttempts stops the form once n answers have been rejected across
+// all of its questions, as a safeguard against input that never gets any
+// better, like a script answering the wrong questions. Run then returns the
+// answers so far and an error matching ErrFormAttempts. Unlike MaxAttempts,
+// the attempts aren't counted for each question. Zero or less means there's
+// no limit.
+func (f *Form) MaxTotalAttempts(n int) *Form {
+	f.maxTotalAttempts = n
+	return f
+}
+
+func (f *Form) add(key, prompt string, password bool) *Question {
+	q := newQuestion(f.prompter).Key(key)
+	f.fields = append(f.fields, &formField{key, prompt, q, password})
+	return q
+}
+
+// Run asks the questions in the order they were added and returns the answers
+// by key. If a question fails or the context is cancelled, Run stops and
+// returns the answers collected so far along with the error.
+func (f *Form) Run(ctx context.Context) (map[string]string, error) {
+	answers := make(map[string]string, len(f.fields))
+	var budget *attemptBudget
+	if f.maxTotalAttempts > 0 {
+		budget = &attemptBudget{left: f.maxTotalAttempts}
+	}
+	for _, field := range f.fields {
+		field.question.budget = budget
+	}
+	for i := 0; i < len(f.fields); {
+		field := f.fields[i]
+		if f.progress {
+			field.question.Progress(i+1, len(f.fields))
+		}
+		answer, err := field.ask(ctx, f.back, answers[field.key])
+		if err != nil {
+			// Go back to the previous question, if there is one
+			if errors.Is(err, errBack) {
+				i = max(i-1, 0)
+				continue
+			}
+			return answers, err
+		}
+		answers[field.key] = answer
+		i++
+	}
+	return answers, nil
+}
+
+// ask the question. When the question was already answered, the previous
+// answer is the default.
+func (f *formField) ask(ctx context.Context, back, previous string) (string, error) {
+	q := f.question
+	q.back = back
+	if previous != "" {
+		defaultTo := q.defaultTo
+		q.defaultTo = previous
+		defer func() { q.defaultTo = defaultTo }()
+	}
+	if f.password {
+		return q.Password(ctx, f.prompt)
+	}
+	return q.Ask(ctx, f.prompt)
+}
@@ -0,0 +1,123 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestForm(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Al\nAlice\nsecret\n\n\n")
+	prompt := prompter.New(writer, reader)
+	form := prompt.Form()
+	form.Ask("name", "What is your name?").Is(prompter.MinLength(3))
+	form.Password("password", "Password:")
+	form.Ask("country", "Country?").Default("NZ")
+	form.Ask("company", "Company?").Optional(true)
+	answers, err := form.Run(ctx)
+	is.NoErr(err)
+	is.Equal(answers, map[string]string{
+		"name":     "Alice",
+		"password": "secret",
+		"country":  "NZ",
+		"company":  "",
+	})
+	diff.TestString(t, writer.String(), "What is your name? must be at least 3 characters, got 2\nWhat is your name? Password: \nCountry? [NZ] Company? ")
+}
+
+func TestFormError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Alice\n")
+	prompt := prompter.New(&bytes.Buffer{}, reader)
+	form := prompt.Form()
+	form.Ask("name", "What is your name?")
+	form.Ask("country", "Country?")
+	form.Ask("company", "Company?")
+	answers, err := form.Run(ctx)
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(answers, map[string]string{"name": "Alice"})
+}
+
+func TestFormCancel(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reader := bytes.NewBufferString("Alice\n")
+	prompt := prompter.New(&bytes.Buffer{}, reader)
+	form := prompt.Form()
+	form.Ask("name", "What is your name?")
+	answers, err := form.Run(ctx)
+	is.True(errors.Is(err, context.Canceled))
+	is.Equal(answers, map[string]string{})
+}
+
+func TestFormBack(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("<\nAlcie\nNZ\n<\n<\nAlice\n\n<\n\nAcme\n")
+	prompt := prompter.New(writer, reader)
+	form := prompt.Form().Back("<")
+	form.Ask("name", "What is your name?").Is(prompter.MinLength(3))
+	form.Ask("country", "Country?")
+	form.Ask("company", "Company?")
+	answers, err := form.Run(ctx)
+	is.NoErr(err)
+	is.Equal(answers, map[string]string{
+		"name":    "Alice",
+		"country": "NZ",
+		"company": "Acme",
+	})
+	diff.TestString(t, writer.String(), "What is your name? What is your name? Country? Company? Country? [NZ] What is your name? [Alcie] Country? [NZ] Company? Country? [NZ] Company? ")
+}
+
+func TestFormBackDisabled(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Alice\n<\n")
+	prompt := prompter.New(&bytes.Buffer{}, reader)
+	form := prompt.Form()
+	form.Ask("name", "What is your name?")
+	form.Ask("country", "Country?")
+	answers, err := form.Run(ctx)
+	is.NoErr(err)
+	is.Equal(answers["country"], "<")
+}
+
+func TestFormProgress(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Alice\n<\n\nNZ\n")
+	prompt := prompter.New(writer, reader)
+	form := prompt.Form().Progress(true).Back("<")
+	form.Ask("name", "What is your name?")
+	form.Ask("country", "Country?")
+	_, err := form.Run(ctx)
+	is.NoErr(err)
+	diff.TestString(t, writer.String(), "(1/2) What is your name? (2/2) Country? (1/2) What is your name? [Alice] (2/2) Country? ")
+}
+
+func TestFormMaxTotalAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Alice\nabc\n30\nxyz\n\nfoo\n")
+	prompt := prompter.New(&bytes.Buffer{}, reader)
+	form := prompt.Form().MaxTotalAttempts(3)
+	form.Ask("name", "What is your name?")
+	form.Ask("age", "How old are you?").Is(prompter.IntRange(0, 150))
+	form.Ask("port", "Port?").Is(prompter.IntRange(1, 65535))
+	answers, err := form.Run(ctx)
+	is.True(errors.Is(err, prompter.ErrFormAttempts))
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(answers, map[string]string{"name": "Alice", "age": "30"})
+}
@@ -0,0 +1,91 @@
+package prompter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// FuzzySelect asks the user to choose one of the options by typing part of it
+func (p *Prompt) FuzzySelect(ctx context.Context, prompt string, options []string) (string, error) {
+	q := newQuestion(p)
+	return q.FuzzySelect(ctx, prompt, options)
+}
+
+// FuzzySelect asks the user to choose one of the options by typing part of
+// it, which works better than Select for long lists. The input is matched
+// case-insensitively, first as the whole option, then as the start of an
+// option, then as letters that appear in order in an option, like "usw2" for
+// "us-west-2". An exact match always wins. If the input matches several
+// options, they're listed and the question is asked again. An empty string is
+// returned when the question is optional and nothing was entered.
+func (q *Question) FuzzySelect(ctx context.Context, prompt string, options []string) (string, error) {
+	// Resolve the input to the option it matches
+	defer q.withValidator(func(s string) (string, error) {
+		if s == "" {
+			return s, nil
+		}
+		return fuzzyMatch(options, s)
+	})()
+
+	input, err := q.Ask(ctx, prompt)
+	if err != nil {
+		return "", err
+	} else if input == "" {
+		return "", nil
+	}
+
+	option, err := fuzzyMatch(options, input)
+	if err != nil {
+		return "", fmt.Errorf("prompter: %w", err)
+	}
+	return option, nil
+}
+
+// fuzzyMatch finds the option the input refers to
+func fuzzyMatch(options []string, input string) (string, error) {
+	for _, option := range options {
+		if option == input {
+			return option, nil
+		}
+	}
+	matchers := []func(option, input string) bool{
+		strings.EqualFold,
+		func(option, input string) bool {
+			return strings.HasPrefix(strings.ToLower(option), strings.ToLower(input))
+		},
+		isSubsequence,
+	}
+	for _, match := range matchers {
+		var matches []string
+		for _, option := range options {
+			if match(option, input) {
+				matches = append(matches, option)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			return "", fmt.Errorf("%q matches %s", input, strings.Join(matches, ", "))
+		}
+	}
+	return "", fmt.Errorf("%q doesn't match any option", input)
+}
+
+// isSubsequence is true when the runes in input appear in order in option,
+// ignoring case
+func isSubsequence(option, input string) bool {
+	remaining := []rune(strings.ToLower(input))
+	for _, r := range strings.ToLower(option) {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
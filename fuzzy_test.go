@@ -0,0 +1,76 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+var regions = []string{"us-east-1", "us-east-2", "us-west-1", "us-west-2", "eu-west-1", "ap-south-1"}
+
+func TestFuzzySelect(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("us-east\nmars\nusw2\n")
+	prompt := prompter.New(writer, reader)
+	region, err := prompt.FuzzySelect(ctx, "Region?", regions)
+	is.NoErr(err)
+	is.Equal(region, "us-west-2")
+	diff.TestString(t, writer.String(), "Region? \"us-east\" matches us-east-1, us-east-2\nRegion? \"mars\" doesn't match any option\nRegion? ")
+}
+
+func TestFuzzySelectPrefix(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("AP\neu\n")
+	prompt := prompter.New(io.Discard, reader)
+	region, err := prompt.FuzzySelect(ctx, "Region?", regions)
+	is.NoErr(err)
+	is.Equal(region, "ap-south-1")
+	region, err = prompt.FuzzySelect(ctx, "Region?", regions)
+	is.NoErr(err)
+	is.Equal(region, "eu-west-1")
+}
+
+func TestFuzzySelectExact(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("go\nGO\n")
+	prompt := prompter.New(io.Discard, reader)
+	options := []string{"go", "golang", "Go"}
+	lang, err := prompt.FuzzySelect(ctx, "Language?", options)
+	is.NoErr(err)
+	is.Equal(lang, "go")
+	// Case-insensitive matches are ambiguous when several options differ only
+	// by case
+	_, err = prompt.Once().FuzzySelect(ctx, "Language?", options)
+	is.True(errors.Is(err, prompter.ErrValidation))
+	is.Equal(err.Error(), `"GO" matches go, Go`)
+}
+
+func TestFuzzySelectDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(io.Discard, reader)
+	region, err := prompt.Default("usw2").FuzzySelect(ctx, "Region?", regions)
+	is.NoErr(err)
+	is.Equal(region, "us-west-2")
+}
+
+func TestFuzzySelectOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(io.Discard, reader)
+	region, err := prompt.Optional(true).FuzzySelect(ctx, "Region?", regions)
+	is.NoErr(err)
+	is.Equal(region, "")
+}
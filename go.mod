@@ -5,7 +5,9 @@ go 1.23.0
 require (
 	github.com/matryer/is v1.4.1
 	github.com/matthewmueller/diff v0.0.3
+	golang.org/x/sys v0.27.0
 	golang.org/x/term v0.26.0
+	golang.org/x/text v0.15.0
 )
 
 require (
@@ -14,9 +16,7 @@ require (
 	github.com/lithammer/dedent v1.1.0 // indirect
 	github.com/sergi/go-diff v1.0.0 // indirect
 	github.com/shurcooL/go-goon v0.0.0-20170922171312-37c2f522c041 // indirect
-	golang.org/x/mod v0.5.1 // indirect
-	golang.org/x/sys v0.27.0 // indirect
-	golang.org/x/tools v0.1.8-0.20211102182255-bb4add04ddef // indirect
-	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+	golang.org/x/mod v0.8.0 // indirect
+	golang.org/x/tools v0.6.0 // indirect
 	mvdan.cc/gofumpt v0.2.0 // indirect
 )
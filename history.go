@@ -0,0 +1,78 @@
+package prompter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// History of answers that can be recalled with the up and down arrows while
+// typing on a terminal. The zero value is an empty history that's ready to use.
+type History struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+// History sets the history of answers to recall while typing. Answers typed
+// on a terminal are added to the history. The history is ignored when the
+// input isn't a terminal.
+func (p *Prompt) History(history *History) *Prompt {
+	p.history = history
+	return p
+}
+
+// Add an entry to the history. Empty entries and entries that repeat the
+// previous entry are skipped.
+func (h *History) Add(entry string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.add(entry)
+}
+
+func (h *History) add(entry string) {
+	if strings.TrimSpace(entry) == "" {
+		return
+	} else if len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry {
+		return
+	}
+	h.entries = append(h.entries, entry)
+}
+
+// Entries returns a copy of the entries from oldest to newest
+func (h *History) Entries() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string{}, h.entries...)
+}
+
+// Load entries from a reader, one entry per line. The entries are added after
+// any existing entries.
+func (h *History) Load(r io.Reader) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		h.add(strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("prompter: unable to load history: %w", err)
+	}
+	return nil
+}
+
+// Save the entries to a writer, one entry per line
+func (h *History) Save(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	bw := bufio.NewWriter(w)
+	for _, entry := range h.entries {
+		bw.WriteString(entry)
+		bw.WriteByte('\n')
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("prompter: unable to save history: %w", err)
+	}
+	return nil
+}
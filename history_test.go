@@ -0,0 +1,45 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestHistory(t *testing.T) {
+	is := is.New(t)
+	history := new(prompter.History)
+	history.Add("dev")
+	history.Add("dev")
+	history.Add("")
+	history.Add("prod")
+	is.Equal(history.Entries(), []string{"dev", "prod"})
+	buf := new(bytes.Buffer)
+	is.NoErr(history.Save(buf))
+	is.Equal(buf.String(), "dev\nprod\n")
+}
+
+func TestHistoryLoad(t *testing.T) {
+	is := is.New(t)
+	history := new(prompter.History)
+	history.Add("dev")
+	is.NoErr(history.Load(strings.NewReader("staging\r\n\nprod\nprod\n")))
+	is.Equal(history.Entries(), []string{"dev", "staging", "prod"})
+}
+
+func TestHistoryNoTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	history := new(prompter.History)
+	reader := bytes.NewBufferString("dev\n")
+	prompt := prompter.New(os.Stdout, reader).History(history)
+	env, err := prompt.Ask(ctx, "Which environment?")
+	is.NoErr(err)
+	is.Equal(env, "dev")
+	is.Equal(len(history.Entries()), 0)
+}
@@ -0,0 +1,73 @@
+package prompter
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// IdleTimeout sets how long to wait between key presses
+func (p *Prompt) IdleTimeout(timeout time.Duration) *Question {
+	q := newQuestion(p)
+	return q.IdleTimeout(timeout)
+}
+
+// IdleTimeout sets how long to wait between key presses before using the
+// default or returning ErrTimeout. Without the line editor or a mask, it
+// applies to the whole line, like Timeout.
+func (q *Question) IdleTimeout(timeout time.Duration) *Question {
+	q.idleTimeout = timeout
+	return q
+}
+
+// idleTimer runs out when it isn't reset in time
+type idleTimer struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	timeout time.Duration
+	stopped bool
+}
+
+func newIdleTimer(timeout time.Duration, expire func()) *idleTimer {
+	return &idleTimer{timer: time.AfterFunc(timeout, expire), timeout: timeout}
+}
+
+// reset starts the timer over, unless it's been stopped
+func (t *idleTimer) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.stopped {
+		t.timer.Reset(t.timeout)
+	}
+}
+
+// stop the timer for good
+func (t *idleTimer) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	t.timer.Stop()
+}
+
+// keyReader returns the reader for key presses, which resets the idle timer
+// on each key
+func (q *Question) keyReader() io.RuneReader {
+	if q.idle == nil {
+		return q.prompter.reader
+	}
+	return &idleReader{q.prompter.reader, q.idle}
+}
+
+// idleReader resets the idle timer each time a key is read
+type idleReader struct {
+	r    io.RuneReader
+	idle *idleTimer
+}
+
+func (r *idleReader) ReadRune() (rune, int, error) {
+	ch, size, err := r.r.ReadRune()
+	if err == nil {
+		r.idle.reset()
+	}
+	return ch, size, err
+}
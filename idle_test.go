@@ -0,0 +1,40 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	r, w := io.Pipe()
+	defer w.Close()
+	writer := new(bytes.Buffer)
+	prompt := prompter.New(writer, r)
+	// Without a terminal, the idle timeout covers the whole line
+	go w.Write([]byte("Al"))
+	name, err := prompt.IdleTimeout(10*time.Millisecond).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrTimeout))
+	is.Equal(name, "")
+	diff.TestString(t, writer.String(), "What is your name? \n")
+}
+
+func TestIdleTimeoutDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	r, w := io.Pipe()
+	defer w.Close()
+	prompt := prompter.New(io.Discard, r)
+	name, err := prompt.Default("Alice").IdleTimeout(10*time.Millisecond).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+}
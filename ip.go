@@ -0,0 +1,31 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// AskIP asks for an IPv4 or IPv6 address and returns it
+func (p *Prompt) AskIP(ctx context.Context, prompt string) (net.IP, error) {
+	q := newQuestion(p)
+	return q.AskIP(ctx, prompt)
+}
+
+// AskIP asks for an IPv4 or IPv6 address and returns it. Validators run
+// against the input before it's parsed. nil is returned when the question is
+// optional and nothing was entered.
+func (q *Question) AskIP(ctx context.Context, prompt string) (net.IP, error) {
+	return askParse(ctx, q, prompt, func(s string) (net.IP, error) {
+		ip := parseIP(s)
+		if ip == nil {
+			return nil, errors.New("please enter an IP address")
+		}
+		return ip, nil
+	})
+}
+
+func parseIP(s string) net.IP {
+	return net.ParseIP(strings.TrimSpace(s))
+}
@@ -0,0 +1,56 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskIP(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("localhost\n256.0.0.1\n::1\n")
+	prompt := prompter.New(writer, reader)
+	ip, err := prompt.AskIP(ctx, "Address?")
+	is.NoErr(err)
+	is.True(ip.Equal(net.IPv6loopback))
+	diff.TestString(t, writer.String(), "Address? please enter an IP address\nAddress? please enter an IP address\nAddress? ")
+}
+
+func TestAskIPDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	ip, err := prompt.Default("127.0.0.1").AskIP(ctx, "Address?")
+	is.NoErr(err)
+	is.Equal(ip.String(), "127.0.0.1")
+}
+
+func TestAskIPOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	ip, err := prompt.Optional(true).AskIP(ctx, "Address?")
+	is.NoErr(err)
+	is.Equal(ip, nil)
+}
+
+func TestAskIPErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	ip, err := prompt.AskIP(ctx, "Address?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(ip, nil)
+}
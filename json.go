@@ -0,0 +1,97 @@
+package prompter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// AskJSON asks for a JSON value and unmarshals it into v
+func (p *Prompt) AskJSON(ctx context.Context, prompt string, v any) error {
+	q := newQuestion(p)
+	return q.AskJSON(ctx, prompt, v)
+}
+
+// AskJSON asks for a JSON value and unmarshals it into v, which must be a
+// non-nil pointer. Values may span multiple lines, so large objects can be
+// pasted in. Lines are read until the value is complete or the input ends.
+// Invalid JSON is reported with its offset and the question is asked again.
+// v is left untouched when the question is optional and nothing was entered.
+func (q *Question) AskJSON(ctx context.Context, prompt string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("prompter: AskJSON needs a non-nil pointer, got %T", v)
+	}
+
+	// Add a validator to ensure the input unmarshals. A new value is used so v
+	// isn't modified by invalid inputs.
+	defer q.withValidator(check(func(s string) error {
+		if s == "" {
+			return nil
+		}
+		return unmarshalJSON(s, reflect.New(rv.Type().Elem()).Interface())
+	}))()
+
+	input, err := q.ask(ctx, prompt, false, func(ctx context.Context) (string, error) {
+		return q.readAsync(ctx, q.scanJSON)
+	})
+	if err != nil {
+		return err
+	} else if input == "" {
+		return nil
+	}
+
+	if err := unmarshalJSON(input, v); err != nil {
+		return fmt.Errorf("prompter: invalid default %q: %w", input, err)
+	}
+	return nil
+}
+
+// scanJSON reads lines until they form a complete JSON value or the input
+// ends
+func (q *Question) scanJSON() (string, error) {
+	p := q.prompter
+	var input strings.Builder
+	for {
+		line, err := p.readString()
+		p.trackEOF(line, err)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		input.WriteString(line)
+		text := strings.TrimSpace(input.String())
+		if err == nil {
+			// Keep reading while the value is incomplete
+			if text != "" && incompleteJSON(text) {
+				continue
+			}
+			return text, nil
+		}
+		// We've reached the end of the input
+		if text == "" {
+			return q.onEOF(text)
+		}
+		return text, nil
+	}
+}
+
+// incompleteJSON is true when the JSON value ends before it's complete
+func incompleteJSON(s string) bool {
+	var raw json.RawMessage
+	err := json.NewDecoder(strings.NewReader(s)).Decode(&raw)
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// unmarshalJSON unmarshals the input, adding the offset to syntax errors
+func unmarshalJSON(s string, v any) error {
+	err := json.Unmarshal([]byte(s), v)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("invalid JSON at offset %d: %s", syntaxErr.Offset, syntaxErr)
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+type jsonConfig struct {
+	Name  string   `json:"name"`
+	Ports []int    `json:"ports"`
+	Tags  []string `json:"tags"`
+}
+
+func TestAskJSON(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("{name: \"api\"}\n{\"name\": \"api\", \"ports\": [80, 443]}\n")
+	prompt := prompter.New(writer, reader)
+	var config jsonConfig
+	is.NoErr(prompt.AskJSON(ctx, "Config?", &config))
+	is.Equal(config.Name, "api")
+	is.Equal(config.Ports, []int{80, 443})
+	diff.TestString(t, writer.String(), "Config? invalid JSON at offset 2: invalid character 'n' looking for beginning of object key string\nConfig? ")
+}
+
+func TestAskJSONMultiline(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("{\n  \"name\": \"api\",\n\n  \"tags\": [\n    \"web\"\n  ]\n}\nnext\n")
+	prompt := prompter.New(os.Stdout, reader)
+	var config jsonConfig
+	is.NoErr(prompt.AskJSON(ctx, "Config?", &config))
+	is.Equal(config.Name, "api")
+	is.Equal(config.Tags, []string{"web"})
+	next, err := prompt.Ask(ctx, "Next?")
+	is.NoErr(err)
+	is.Equal(next, "next")
+}
+
+func TestAskJSONType(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("{\"ports\": \"80\"}\n{\"ports\": [80]}\n")
+	prompt := prompter.New(writer, reader)
+	config := jsonConfig{Name: "api"}
+	is.NoErr(prompt.AskJSON(ctx, "Config?", &config))
+	is.Equal(config.Name, "api")
+	is.Equal(config.Ports, []int{80})
+	diff.TestString(t, writer.String(), "Config? json: cannot unmarshal string into Go struct field jsonConfig.ports of type []int\nConfig? ")
+}
+
+func TestAskJSONEOF(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("[1,\n2,\n3]")
+	prompt := prompter.New(os.Stdout, reader)
+	var numbers []int
+	is.NoErr(prompt.AskJSON(ctx, "Numbers?", &numbers))
+	is.Equal(numbers, []int{1, 2, 3})
+}
+
+func TestAskJSONOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	config := jsonConfig{Name: "api"}
+	is.NoErr(prompt.Optional(true).AskJSON(ctx, "Config?", &config))
+	is.Equal(config, jsonConfig{Name: "api"})
+}
+
+func TestAskJSONErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	var config jsonConfig
+	err := prompt.AskJSON(ctx, "Config?", &config)
+	is.True(errors.Is(err, prompter.ErrRequired))
+}
+
+func TestAskJSONNotPointer(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("{}\n")
+	prompt := prompter.New(os.Stdout, reader)
+	var config jsonConfig
+	err := prompt.AskJSON(ctx, "Config?", config)
+	is.Equal(err.Error(), "prompter: AskJSON needs a non-nil pointer, got prompter_test.jsonConfig")
+}
@@ -0,0 +1,136 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// ReadKey reads a single key press without waiting for enter. On a terminal,
+// the terminal is put into raw mode for the key press and Ctrl-C returns
+// ErrInterrupted. Otherwise, the next line is read and its first character is
+// returned. Enter is returned as '\n'.
+func (p *Prompt) ReadKey(ctx context.Context) (rune, error) {
+	q := newQuestion(p)
+	key, err := q.readAsync(ctx, q.scanKey)
+	if err != nil {
+		return 0, err
+	}
+	r, _ := utf8.DecodeRuneInString(key)
+	return r, nil
+}
+
+// scanKey reads a single key press. It's returned as a string to work with
+// readAsync.
+func (q *Question) scanKey() (string, error) {
+	p := q.prompter
+	if !p.isTerminal() {
+		line, err := p.readString()
+		p.trackEOF(line, err)
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			if errors.Is(err, io.EOF) {
+				return "", closedError{}
+			}
+			return "", err
+		}
+		if line = q.trimLineEnding(line); line == "" {
+			return "\n", nil
+		}
+		return line, nil
+	}
+	restore, err := p.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+	r, _, err := p.reader.ReadRune()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", closedError{}
+		}
+		return "", err
+	}
+	switch r {
+	case keyCtrlC:
+		return "", ErrInterrupted
+	case '\r':
+		return "\n", nil
+	}
+	return string(r), nil
+}
+
+// ConfirmKey asks for a confirmation that's answered with a single key press
+func (p *Prompt) ConfirmKey(ctx context.Context, prompt string, def bool) (bool, error) {
+	q := newQuestion(p)
+	return q.ConfirmKey(ctx, prompt, def)
+}
+
+// ConfirmKey asks for a confirmation that's answered by pressing y or n,
+// without waiting for enter. Enter uses the default and other keys are
+// ignored. The prompt is followed by a [Y/n] or [y/N] hint, depending on the
+// default. When the input isn't a terminal, this falls back to ConfirmDefault.
+func (q *Question) ConfirmKey(ctx context.Context, prompt string, def bool) (bool, error) {
+	p := q.prompter
+	if !p.isTerminal() {
+		return q.ConfirmDefault(ctx, prompt, def)
+	}
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+	if err := q.writePrompt(prompt+" "+hint, false); err != nil {
+		return false, err
+	}
+	key, err := q.readAsync(ctx, func() (string, error) {
+		return q.scanConfirmKey(def)
+	})
+	if err != nil {
+		return false, q.cancelled(ctx, err)
+	}
+	p.answered(prompt, key, false)
+	return key == "y", nil
+}
+
+// scanConfirmKey reads key presses until y, n or enter is pressed, then
+// echoes the answer
+func (q *Question) scanConfirmKey(def bool) (string, error) {
+	p := q.prompter
+	restore, err := p.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+	for {
+		r, _, err := p.reader.ReadRune()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", closedError{}
+			}
+			return "", err
+		}
+		answer := ""
+		switch r {
+		case 'y', 'Y':
+			answer = "y"
+		case 'n', 'N':
+			answer = "n"
+		case '\r', '\n':
+			answer = "n"
+			if def {
+				answer = "y"
+			}
+		case keyCtrlC:
+			if err := p.render("\r\n"); err != nil {
+				return "", err
+			}
+			return "", ErrInterrupted
+		default:
+			continue
+		}
+		if err := p.render(answer + "\r\n"); err != nil {
+			return "", err
+		}
+		return answer, nil
+	}
+}
@@ -0,0 +1,58 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestReadKey(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("yes\n\n日本\nq")
+	prompt := prompter.New(io.Discard, reader)
+	key, err := prompt.ReadKey(ctx)
+	is.NoErr(err)
+	is.Equal(key, 'y')
+	key, err = prompt.ReadKey(ctx)
+	is.NoErr(err)
+	is.Equal(key, '\n')
+	key, err = prompt.ReadKey(ctx)
+	is.NoErr(err)
+	is.Equal(key, '日')
+	key, err = prompt.ReadKey(ctx)
+	is.NoErr(err)
+	is.Equal(key, 'q')
+	_, err = prompt.ReadKey(ctx)
+	is.True(errors.Is(err, prompter.ErrClosed))
+}
+
+func TestReadKeyCancel(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	prompt := prompter.New(io.Discard, bytes.NewBufferString("y\n"))
+	_, err := prompt.ReadKey(ctx)
+	is.True(errors.Is(err, context.Canceled))
+}
+
+func TestConfirmKeyNoTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nn\n")
+	prompt := prompter.New(writer, reader)
+	ok, err := prompt.ConfirmKey(ctx, "Continue?", true)
+	is.NoErr(err)
+	is.True(ok)
+	ok, err = prompt.ConfirmKey(ctx, "Continue?", true)
+	is.NoErr(err)
+	is.True(!ok)
+	diff.TestString(t, writer.String(), "Continue? [Y/n] Continue? [Y/n] ")
+}
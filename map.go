@@ -0,0 +1,85 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AskMap asks for a list of key=value pairs separated by commas and returns
+// them as a map
+func (p *Prompt) AskMap(ctx context.Context, prompt string) (map[string]string, error) {
+	q := newQuestion(p)
+	return q.AskMap(ctx, prompt)
+}
+
+// DuplicateKeys allows keys to be repeated in AskMap, with the last value
+// winning
+func (p *Prompt) DuplicateKeys(allow bool) *Question {
+	q := newQuestion(p)
+	q.duplicateKeys = allow
+	return q
+}
+
+// DuplicateKeys allows keys to be repeated in AskMap, with the last value
+// winning. By default, a repeated key is invalid and the question is asked
+// again.
+func (q *Question) DuplicateKeys(allow bool) *Question {
+	q.duplicateKeys = allow
+	return q
+}
+
+// AskMap asks for a list of key=value pairs separated by commas, like
+// "PORT=3000, ENV=dev", and returns them as a map. Keys and values are
+// trimmed and values may be empty. Validators run against each value and the
+// whole list is asked for again if any pair is invalid. An empty map is
+// returned when the question is optional and nothing was entered. The default
+// may also be a list of pairs.
+func (q *Question) AskMap(ctx context.Context, prompt string) (map[string]string, error) {
+	// Validate each value in the list, rather than the whole input
+	validators := q.validators
+	defer func() { q.validators = validators }()
+	q.validators = []func(string) (string, error){func(s string) (string, error) {
+		pairs, err := q.parseMap(s)
+		if err != nil {
+			return s, err
+		} else if len(pairs) == 0 && !q.optional {
+			return s, errors.New("must enter at least one key=value pair")
+		}
+		for _, value := range pairs {
+			if _, err := runValidators(validators, value); err != nil {
+				return s, err
+			}
+		}
+		return s, nil
+	}}
+
+	input, err := q.Ask(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := q.parseMap(input)
+	if err != nil {
+		return nil, fmt.Errorf("prompter: %w", err)
+	}
+	return pairs, nil
+}
+
+// parseMap parses a list of key=value pairs separated by commas
+func (q *Question) parseMap(input string) (map[string]string, error) {
+	pairs := map[string]string{}
+	for _, pair := range splitList(input, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid pair %q, must be key=value", pair)
+		}
+		if _, exists := pairs[key]; exists && !q.duplicateKeys {
+			return nil, fmt.Errorf("duplicate key %q", key)
+		}
+		pairs[key] = strings.TrimSpace(value)
+	}
+	return pairs, nil
+}
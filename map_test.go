@@ -0,0 +1,78 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskMap(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("PORT\nPORT=1,PORT=2\n PORT = 3000 , ENV=dev, DEBUG=\n")
+	prompt := prompter.New(writer, reader)
+	env, err := prompt.AskMap(ctx, "Environment?")
+	is.NoErr(err)
+	is.Equal(env, map[string]string{"PORT": "3000", "ENV": "dev", "DEBUG": ""})
+	diff.TestString(t, writer.String(), "Environment? invalid pair \"PORT\", must be key=value\nEnvironment? duplicate key \"PORT\"\nEnvironment? ")
+}
+
+func TestAskMapDuplicateKeys(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("PORT=1,PORT=2\n")
+	prompt := prompter.New(io.Discard, reader)
+	env, err := prompt.DuplicateKeys(true).AskMap(ctx, "Environment?")
+	is.NoErr(err)
+	is.Equal(env, map[string]string{"PORT": "2"})
+}
+
+func TestAskMapValidators(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("ENV=staging\nENV=dev\n")
+	prompt := prompter.New(writer, reader)
+	env, err := prompt.Is(prompter.OneOf("dev", "prod")).AskMap(ctx, "Environment?")
+	is.NoErr(err)
+	is.Equal(env, map[string]string{"ENV": "dev"})
+	diff.TestString(t, writer.String(), "Environment? invalid value \"staging\", must be one of dev, prod\nEnvironment? ")
+}
+
+func TestAskMapOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(io.Discard, reader)
+	env, err := prompt.Optional(true).AskMap(ctx, "Environment?")
+	is.NoErr(err)
+	is.Equal(env, map[string]string{})
+}
+
+func TestAskMapRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString(",\n")
+	prompt := prompter.New(writer, reader)
+	_, err := prompt.AskMap(ctx, "Environment?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	diff.TestString(t, writer.String(), "Environment? must enter at least one key=value pair\nEnvironment? ")
+}
+
+func TestAskMapDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(io.Discard, reader)
+	env, err := prompt.Default("ENV=dev").AskMap(ctx, "Environment?")
+	is.NoErr(err)
+	is.Equal(env, map[string]string{"ENV": "dev"})
+}
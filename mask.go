@@ -0,0 +1,106 @@
+package prompter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaskReveal shows each character typed into a password for a moment before
+// masking it
+func (p *Prompt) MaskReveal(mask rune, reveal time.Duration) *Question {
+	q := newQuestion(p)
+	return q.MaskReveal(mask, reveal)
+}
+
+// MaskReveal shows each character typed into a password on a terminal for the
+// reveal duration, then replaces it with the mask, like the password fields
+// on phones. Typing the next character masks the previous one right away.
+// When the input isn't a terminal, the password is read without echoing it.
+func (q *Question) MaskReveal(mask rune, reveal time.Duration) *Question {
+	q.mask = mask
+	q.reveal = reveal
+	return q
+}
+
+// masker echoes the mask for each character typed, optionally revealing the
+// last character until it's hidden by a timer or the next key press. Without
+// a mask, characters are echoed as typed.
+type masker struct {
+	mu     sync.Mutex
+	w      io.Writer
+	mask   rune
+	reveal time.Duration
+
+	// revealed is the character being shown, or 0 if nothing is shown. The
+	// generation is bumped each time a character is revealed, so a timer for
+	// an older character doesn't hide a newer one.
+	revealed   rune
+	generation int
+	timer      *time.Timer
+}
+
+// echo writes the character if it should be revealed, otherwise the mask
+func (m *masker) echo(ch rune) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hideLocked()
+	if m.mask == 0 {
+		fmt.Fprint(m.w, string(ch))
+		return
+	}
+	if m.reveal <= 0 {
+		fmt.Fprint(m.w, string(m.mask))
+		return
+	}
+	fmt.Fprint(m.w, string(ch))
+	m.revealed = ch
+	m.generation++
+	generation := m.generation
+	m.timer = time.AfterFunc(m.reveal, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.generation == generation {
+			m.hideLocked()
+		}
+	})
+}
+
+// hide replaces the revealed character with the mask
+func (m *masker) hide() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hideLocked()
+}
+
+func (m *masker) hideLocked() {
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	if m.revealed == 0 {
+		return
+	}
+	cursorBack(m.w, runeWidth(m.revealed))
+	fmt.Fprint(m.w, string(m.mask))
+	// Clear what's left of a character that's wider than the mask
+	if pad := runeWidth(m.revealed) - runeWidth(m.mask); pad > 0 {
+		fmt.Fprint(m.w, strings.Repeat(" ", pad))
+		cursorBack(m.w, pad)
+	}
+	m.revealed = 0
+}
+
+// erase erases the last mask on the line, or the character itself when
+// there's no mask. Wide characters take up two columns.
+func (m *masker) erase(ch rune) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cols := runeWidth(m.mask)
+	if m.mask == 0 {
+		cols = runeWidth(ch)
+	}
+	fmt.Fprint(m.w, strings.Repeat("\b", cols)+strings.Repeat(" ", cols)+strings.Repeat("\b", cols))
+}
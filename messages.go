@@ -0,0 +1,70 @@
+package prompter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Messages are the built-in messages for required inputs and confirmations,
+// which can be changed to localize them. Empty fields fall back to the default
+// English messages. Other messages, like the errors from validators, aren't
+// covered. Nothing is written after a password other than a newline, so
+// there's no message for it.
+type Messages struct {
+	// Required is the message of the error returned when a required input is
+	// empty. The error still matches ErrRequired.
+	Required string
+	// ConfirmInvalid is shown when a confirmation isn't yes or no. It's a
+	// format string with exactly one verb for the input, like
+	// "invalid value %q".
+	ConfirmInvalid string
+	// ConfirmWithInvalid is shown when the input to ConfirmWith isn't one of
+	// its words. It's a format string with exactly two verbs, the first for
+	// the input and the second for the words joined by commas, like
+	// "invalid value %q, must enter one of %s".
+	ConfirmWithInvalid string
+}
+
+// WithMessages sets the built-in messages
+func (p *Prompt) WithMessages(messages Messages) *Prompt {
+	p.messages = messages
+	return p
+}
+
+// requiredError is ErrRequired with a custom message
+type requiredError struct {
+	msg string
+}
+
+func (e *requiredError) Error() string {
+	return e.msg
+}
+
+func (e *requiredError) Is(target error) bool {
+	return target == ErrRequired
+}
+
+// errRequired returns the error for an empty input on a required question
+func (p *Prompt) errRequired() error {
+	if p.messages.Required == "" {
+		return ErrRequired
+	}
+	return &requiredError{p.messages.Required}
+}
+
+// errConfirmInvalid returns the error for an invalid confirmation
+func (p *Prompt) errConfirmInvalid(input string) error {
+	if p.messages.ConfirmInvalid == "" {
+		return fmt.Errorf("invalid value %q, must enter yes or no", input)
+	}
+	return fmt.Errorf(p.messages.ConfirmInvalid, input)
+}
+
+// errConfirmWithInvalid returns the error for input that isn't one of the
+// ConfirmWith words
+func (p *Prompt) errConfirmWithInvalid(input string, words []string) error {
+	if p.messages.ConfirmWithInvalid == "" {
+		return fmt.Errorf("invalid value %q, must enter one of %s", input, strings.Join(words, ", "))
+	}
+	return fmt.Errorf(p.messages.ConfirmWithInvalid, input, strings.Join(words, ", "))
+}
@@ -0,0 +1,93 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// AskMultiline asks for multiple lines of input, reading until a line equal to
+// the terminator
+func (p *Prompt) AskMultiline(ctx context.Context, prompt, terminator string) (string, error) {
+	q := newQuestion(p)
+	return q.AskMultiline(ctx, prompt, terminator)
+}
+
+// AskMultiline asks for multiple lines of input, reading until a line equal to
+// the terminator or the end of the input. The lines are joined by newlines
+// without the terminator. Validators run against the joined lines.
+func (q *Question) AskMultiline(ctx context.Context, prompt, terminator string) (string, error) {
+	return q.ask(ctx, prompt, false, func(ctx context.Context) (string, error) {
+		return q.readAsync(ctx, func() (string, error) {
+			return q.scanLines(terminator)
+		})
+	})
+}
+
+// scanLines reads lines until the terminator or the end of the input
+func (q *Question) scanLines(terminator string) (string, error) {
+	p := q.prompter
+	var lines []string
+	for {
+		line, err := p.readString()
+		p.trackEOF(line, err)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		line = q.trimLineEnding(line)
+		if err == nil {
+			if line == terminator {
+				break
+			}
+			lines = append(lines, line)
+			continue
+		}
+		// We've reached the end of the input
+		if line != "" && line != terminator {
+			lines = append(lines, line)
+		}
+		if len(lines) == 0 {
+			return q.onEOF("")
+		}
+		break
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// AskAll asks for everything up to the end of the input
+func (p *Prompt) AskAll(ctx context.Context, prompt string) (string, error) {
+	q := newQuestion(p)
+	return q.AskAll(ctx, prompt)
+}
+
+// AskAll asks for everything up to the end of the input, like a config that's
+// pasted in and ended with Ctrl-D. The input is returned as it was read,
+// line endings included. Validators run against the whole input. An empty
+// input is only allowed when the question is optional.
+func (q *Question) AskAll(ctx context.Context, prompt string) (string, error) {
+	return q.ask(ctx, prompt, false, func(ctx context.Context) (string, error) {
+		return q.readAsync(ctx, q.scanAll)
+	})
+}
+
+// scanAll reads until the end of the input
+func (q *Question) scanAll() (string, error) {
+	p := q.prompter
+	data, err := io.ReadAll(p.reader)
+	if err != nil {
+		return "", err
+	}
+	input := validUTF8(string(data))
+	// Skip the \n of a \r\n line ending that arrived after the last line
+	if p.afterCR {
+		p.afterCR = false
+		input = strings.TrimPrefix(input, "\n")
+	}
+	p.trackEOF(input, io.EOF)
+	if input == "" {
+		return q.onEOF(input)
+	}
+	return input, nil
+}
@@ -0,0 +1,102 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskMultiline(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Fix the parser\n\nIt was broken.\n.\nnext\n")
+	prompt := prompter.New(os.Stdout, reader)
+	message, err := prompt.AskMultiline(ctx, "Commit message?", ".")
+	is.NoErr(err)
+	is.Equal(message, "Fix the parser\n\nIt was broken.")
+	next, err := prompt.Ask(ctx, "Next?")
+	is.NoErr(err)
+	is.Equal(next, "next")
+}
+
+func TestAskMultilineEOF(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("first\r\nsecond")
+	prompt := prompter.New(os.Stdout, reader)
+	message, err := prompt.AskMultiline(ctx, "Description?", "EOF")
+	is.NoErr(err)
+	is.Equal(message, "first\nsecond")
+}
+
+func TestAskMultilineValidate(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("a\n.\na\nb\n.\n")
+	prompt := prompter.New(writer, reader)
+	message, err := prompt.Is(prompter.MinLength(3)).AskMultiline(ctx, "Description?", ".")
+	is.NoErr(err)
+	is.Equal(message, "a\nb")
+	diff.TestString(t, writer.String(), "Description? must be at least 3 characters, got 1\nDescription? ")
+}
+
+func TestAskMultilineOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString(".\n")
+	prompt := prompter.New(os.Stdout, reader)
+	message, err := prompt.Optional(true).AskMultiline(ctx, "Description?", ".")
+	is.NoErr(err)
+	is.Equal(message, "")
+}
+
+func TestAskMultilineErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString(".\n")
+	prompt := prompter.New(os.Stdout, reader)
+	message, err := prompt.AskMultiline(ctx, "Description?", ".")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(message, "")
+}
+
+func TestAskAll(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("name\r\nport = 3000\n\n[db]\nurl = \"postgres://\"\n")
+	prompt := prompter.New(os.Stdout, reader)
+	name, err := prompt.Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "name")
+	config, err := prompt.AskAll(ctx, "Paste the config, then press Ctrl-D:")
+	is.NoErr(err)
+	is.Equal(config, "port = 3000\n\n[db]\nurl = \"postgres://\"\n")
+}
+
+func TestAskAllEmpty(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.New(os.Stdout, bytes.NewBufferString(""))
+	_, err := prompt.AskAll(ctx, "Config?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	config, err := prompt.Optional(true).AskAll(ctx, "Config?")
+	is.NoErr(err)
+	is.Equal(config, "")
+}
+
+func TestAskAllValidate(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	prompt := prompter.New(writer, bytes.NewBufferString("port = 3000\n"))
+	_, err := prompt.Is(prompter.MatchString(`\[db\]`, "missing the [db] section")).AskAll(ctx, "Config?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	diff.TestString(t, writer.String(), "Config? missing the [db] section\nConfig? ")
+}
@@ -0,0 +1,63 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// AskInt asks for a whole number and returns it
+func (p *Prompt) AskInt(ctx context.Context, prompt string) (int, error) {
+	q := newQuestion(p)
+	return q.AskInt(ctx, prompt)
+}
+
+// AskInt asks for a whole number and returns it. Validators run against the
+// input before it's parsed. 0 is returned when the question is optional and
+// nothing was entered.
+func (q *Question) AskInt(ctx context.Context, prompt string) (int, error) {
+	return askParse(ctx, q, prompt, func(s string) (int, error) {
+		n, err := parseInt(s)
+		if err != nil {
+			return 0, errors.New("please enter a whole number")
+		}
+		return n, nil
+	})
+}
+
+func parseInt(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
+
+// AskFloat asks for a decimal number and returns it
+func (p *Prompt) AskFloat(ctx context.Context, prompt string) (float64, error) {
+	q := newQuestion(p)
+	return q.AskFloat(ctx, prompt)
+}
+
+// AskFloat asks for a decimal number and returns it. NaN and infinite values
+// are rejected. Validators run against the input before it's parsed. 0 is
+// returned when the question is optional and nothing was entered.
+func (q *Question) AskFloat(ctx context.Context, prompt string) (float64, error) {
+	return askParse(ctx, q, prompt, func(s string) (float64, error) {
+		n, err := parseFloat(s)
+		if err != nil {
+			return 0, errors.New("please enter a number")
+		}
+		return n, nil
+	})
+}
+
+func parseFloat(s string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("%q is not a finite number", s)
+	}
+	return n, nil
+}
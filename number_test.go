@@ -0,0 +1,118 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskInt(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("twenty\n2.5\n27\n")
+	prompt := prompter.New(writer, reader)
+	age, err := prompt.AskInt(ctx, "What is your age?")
+	is.NoErr(err)
+	is.Equal(age, 27)
+	diff.TestString(t, writer.String(), "What is your age? please enter a whole number\nWhat is your age? please enter a whole number\nWhat is your age? ")
+}
+
+func TestAskIntDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	age, err := prompt.Default("21").AskInt(ctx, "What is your age?")
+	is.NoErr(err)
+	is.Equal(age, 21)
+}
+
+func TestAskIntOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	age, err := prompt.Optional(true).AskInt(ctx, "What is your age?")
+	is.NoErr(err)
+	is.Equal(age, 0)
+}
+
+func TestAskIntValidate(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("-\n-1\n1\n")
+	prompt := prompter.New(writer, reader)
+	positive := func(s string) error {
+		if len(s) > 0 && s[0] == '-' {
+			return errors.New("must be positive")
+		}
+		return nil
+	}
+	n, err := prompt.Is(positive).AskInt(ctx, "How many?")
+	is.NoErr(err)
+	is.Equal(n, 1)
+	diff.TestString(t, writer.String(), "How many? must be positive\nHow many? must be positive\nHow many? ")
+}
+
+func TestAskFloat(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("cheap\nNaN\n-Inf\n9.99\n")
+	prompt := prompter.New(writer, reader)
+	price, err := prompt.AskFloat(ctx, "Price?")
+	is.NoErr(err)
+	is.Equal(price, 9.99)
+	diff.TestString(t, writer.String(), "Price? please enter a number\nPrice? please enter a number\nPrice? please enter a number\nPrice? ")
+}
+
+func TestAskFloatDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	price, err := prompt.Default("0.5").AskFloat(ctx, "Price?")
+	is.NoErr(err)
+	is.Equal(price, 0.5)
+}
+
+func TestAskFloatOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	price, err := prompt.Optional(true).AskFloat(ctx, "Price?")
+	is.NoErr(err)
+	is.Equal(price, 0.0)
+}
+
+func TestAskIntRange(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("http\n0\n8080\n")
+	prompt := prompter.New(writer, reader)
+	port, err := prompt.Is(prompter.IntRange(1, 65535)).AskInt(ctx, "Port?")
+	is.NoErr(err)
+	is.Equal(port, 8080)
+	diff.TestString(t, writer.String(), "Port? please enter a whole number\nPort? must be between 1 and 65535, got 0\nPort? ")
+}
+
+func TestAskIntInvalidDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	age, err := prompt.Default("old").AskInt(ctx, "What is your age?")
+	is.True(err != nil)
+	is.Equal(err.Error(), `prompter: invalid default "old": please enter a whole number`)
+	is.Equal(age, 0)
+}
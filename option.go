@@ -0,0 +1,133 @@
+package prompter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Option is a choice in a menu with a description
+type Option struct {
+	// Value is returned when the option is chosen
+	Value string
+	// Label is shown in the menu. Defaults to the value.
+	Label string
+	// Description is shown after the label
+	Description string
+	// Disabled options are listed, but can't be chosen
+	Disabled bool
+	// DisabledReason is shown next to a disabled option and when it's chosen
+	DisabledReason string
+}
+
+// label is shown for the option in the menu
+func (o Option) label() string {
+	if o.Label == "" {
+		return o.Value
+	}
+	return o.Label
+}
+
+// disabled returns an error when the option can't be chosen
+func (o Option) disabled() error {
+	if !o.Disabled {
+		return nil
+	} else if o.DisabledReason == "" {
+		return fmt.Errorf("%s is disabled", o.label())
+	}
+	return fmt.Errorf("%s is disabled: %s", o.label(), o.DisabledReason)
+}
+
+// SelectOptions asks the user to choose one of the options and returns the
+// chosen option's value
+func (p *Prompt) SelectOptions(ctx context.Context, prompt string, options []Option) (string, error) {
+	q := newQuestion(p)
+	return q.SelectOptions(ctx, prompt, options)
+}
+
+// SelectOptions asks the user to choose one of the options and returns the
+// chosen option's value. Options are listed as "1) Label — Description". The
+// user may enter the number shown next to the option, its value or its label.
+// Disabled options keep their number, but choosing one writes why it's
+// disabled and asks again. An empty string is returned when the question is
+// optional and nothing was chosen.
+func (q *Question) SelectOptions(ctx context.Context, prompt string, options []Option) (string, error) {
+	// Print out the numbered list of options
+	if err := q.writeDescribed(options); err != nil {
+		return "", err
+	}
+
+	// Add a validator to ensure the input is one of the options
+	defer q.withValidator(check(func(s string) error {
+		if s == "" {
+			return nil
+		}
+		index := findOption(options, s)
+		if index < 0 {
+			return fmt.Errorf("invalid option %q, must choose 1-%d", s, len(options))
+		}
+		return options[index].disabled()
+	}))()
+
+	input, err := q.Ask(ctx, prompt)
+	if err != nil {
+		return "", err
+	} else if input == "" {
+		return "", nil
+	}
+
+	index := findOption(options, input)
+	if index < 0 {
+		return "", fmt.Errorf("prompter: %q is not a valid option", input)
+	} else if err := options[index].disabled(); err != nil {
+		return "", fmt.Errorf("prompter: %w", err)
+	}
+	return options[index].Value, nil
+}
+
+// writeDescribed writes out the numbered list of options with their
+// descriptions. Disabled options are marked and dimmed on terminals.
+func (q *Question) writeDescribed(options []Option) error {
+	p := q.prompter
+	for i, option := range options {
+		line := fmt.Sprintf("%d) %s", i+1, option.label())
+		if option.Description != "" {
+			line += " — " + option.Description
+		}
+		if option.Disabled {
+			line = p.dim(line + disabledHint(option))
+		}
+		if err := p.render(line + "\n"); err != nil {
+			return fmt.Errorf("prompter: unable to write the options: %w", err)
+		}
+	}
+	return nil
+}
+
+// findOption finds the option by its value, its label or its 1-based number,
+// in that order. Returns -1 if there's no match.
+func findOption(options []Option, input string) int {
+	for i, option := range options {
+		if option.Value == input {
+			return i
+		}
+	}
+	for i, option := range options {
+		if option.label() == input {
+			return i
+		}
+	}
+	n, err := strconv.Atoi(input)
+	if err != nil || n < 1 || n > len(options) {
+		return -1
+	}
+	return n - 1
+}
+
+// disabledHint marks a disabled option in the menu
+func disabledHint(option Option) string {
+	if option.DisabledReason == "" {
+		return " (disabled)"
+	}
+	return " (disabled: " + option.DisabledReason + ")"
+}
@@ -0,0 +1,76 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+var plans = []prompter.Option{
+	{Value: "free", Label: "Free", Description: "For side projects"},
+	{Value: "pro", Label: "Pro", Description: "For teams"},
+	{Value: "custom"},
+}
+
+func TestSelectOptions(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("4\nPro\n")
+	prompt := prompter.New(writer, reader)
+	plan, err := prompt.SelectOptions(ctx, "Plan?", plans)
+	is.NoErr(err)
+	is.Equal(plan, "pro")
+	diff.TestString(t, writer.String(), "1) Free — For side projects\n2) Pro — For teams\n3) custom\nPlan? invalid option \"4\", must choose 1-3\nPlan? ")
+}
+
+func TestSelectOptionsMatch(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("1\nfree\n3\n")
+	prompt := prompter.New(new(bytes.Buffer), reader)
+	for _, want := range []string{"free", "free", "custom"} {
+		plan, err := prompt.SelectOptions(ctx, "Plan?", plans)
+		is.NoErr(err)
+		is.Equal(plan, want)
+	}
+}
+
+func TestSelectOptionsDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("\n"))
+	plan, err := prompt.Default("Pro").SelectOptions(ctx, "Plan?", plans)
+	is.NoErr(err)
+	is.Equal(plan, "pro")
+}
+
+func TestSelectOptionsDisabled(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("2\nEnterprise\n1\n")
+	prompt := prompter.New(writer, reader)
+	options := []prompter.Option{
+		{Value: "free", Label: "Free"},
+		{Value: "pro", Label: "Pro", Disabled: true, DisabledReason: "upgrade your card first"},
+		{Value: "enterprise", Label: "Enterprise", Description: "Talk to sales", Disabled: true},
+	}
+	plan, err := prompt.SelectOptions(ctx, "Plan?", options)
+	is.NoErr(err)
+	is.Equal(plan, "free")
+	diff.TestString(t, writer.String(), "1) Free\n"+
+		"2) Pro (disabled: upgrade your card first)\n"+
+		"3) Enterprise — Talk to sales (disabled)\n"+
+		"Plan? Pro is disabled: upgrade your card first\n"+
+		"Plan? Enterprise is disabled\n"+
+		"Plan? ")
+	// A disabled default isn't chosen either
+	prompt = prompter.New(new(bytes.Buffer), bytes.NewBufferString("\n"))
+	_, err = prompt.Default("pro").SelectOptions(ctx, "Plan?", options)
+	is.True(err != nil)
+}
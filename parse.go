@@ -0,0 +1,43 @@
+package prompter
+
+import (
+	"context"
+	"fmt"
+)
+
+// Ask asks a question and parses the input into a value. If the input can't
+// be parsed, the parse error is printed and the question is asked again, just
+// like a failed validator. The zero value is returned when the question is
+// optional and nothing was entered.
+func Ask[T any](ctx context.Context, p *Prompt, prompt string, parse func(string) (T, error)) (T, error) {
+	q := newQuestion(p)
+	return askParse(ctx, q, prompt, parse)
+}
+
+// askParse asks the question and parses the input. Validators run against the
+// input before it's parsed.
+func askParse[T any](ctx context.Context, q *Question, prompt string, parse func(string) (T, error)) (T, error) {
+	var zero T
+
+	// Add a validator to ensure the input can be parsed
+	defer q.withValidator(check(func(s string) error {
+		if s == "" {
+			return nil
+		}
+		_, err := parse(s)
+		return err
+	}))()
+
+	input, err := q.Ask(ctx, prompt)
+	if err != nil {
+		return zero, err
+	} else if input == "" {
+		return zero, nil
+	}
+
+	value, err := parse(input)
+	if err != nil {
+		return zero, fmt.Errorf("prompter: invalid default %q: %w", input, err)
+	}
+	return value, nil
+}
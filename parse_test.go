@@ -0,0 +1,55 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskParse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("soon\n1m30s\n")
+	prompt := prompter.New(writer, reader)
+	interval, err := prompter.Ask(ctx, prompt, "Interval?", time.ParseDuration)
+	is.NoErr(err)
+	is.Equal(interval, 90*time.Second)
+	diff.TestString(t, writer.String(), "Interval? time: invalid duration \"soon\"\nInterval? ")
+}
+
+func TestAskParseCustom(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("localhost\n10.0.0.1\n")
+	prompt := prompter.New(writer, reader)
+	ip, err := prompter.Ask(ctx, prompt, "IP?", func(s string) (net.IP, error) {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, errors.New("please enter an IP address")
+		}
+		return ip, nil
+	})
+	is.NoErr(err)
+	is.Equal(ip.String(), "10.0.0.1")
+	diff.TestString(t, writer.String(), "IP? please enter an IP address\nIP? ")
+}
+
+func TestAskParseErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	interval, err := prompter.Ask(ctx, prompt, "Interval?", time.ParseDuration)
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(interval, time.Duration(0))
+}
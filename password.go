@@ -0,0 +1,105 @@
+package prompter
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+)
+
+// NewPassword asks for a new password twice and returns it once both entries
+// match
+func (p *Prompt) NewPassword(ctx context.Context, prompt, confirmPrompt string) (string, error) {
+	q := newQuestion(p)
+	return q.NewPassword(ctx, prompt, confirmPrompt)
+}
+
+// NewPassword asks for a new password twice and returns it once both entries
+// match. Validators run against the first entry, before asking for the
+// confirmation. If the entries don't match, both are asked for again, which
+// counts as a failed attempt for MaxAttempts and Once, with ErrRejected as
+// the error. There's nothing to confirm when the default is used or nothing
+// was entered.
+func (q *Question) NewPassword(ctx context.Context, prompt, confirmPrompt string) (string, error) {
+	p := q.prompter
+	until := q.until
+	defer func() { q.until = until }()
+	q.until = func(pass string) (bool, error) {
+		if pass == "" {
+			return true, nil
+		}
+		// Echo the password before asking for the confirmation, which is echoed
+		// when the password is accepted
+		if err := p.echo(pass, true); err != nil {
+			return false, err
+		}
+		// The confirmation is checked against the password, not the validators
+		confirm := newQuestion(p).Optional(true)
+		confirm.silent = true
+		confirm.mask, confirm.reveal, confirm.hidden = q.mask, q.reveal, q.hidden
+		confirm.trim, confirm.timeout, confirm.idleTimeout = q.trim, q.timeout, q.idleTimeout
+		again, err := confirm.Password(ctx, confirmPrompt)
+		if err != nil {
+			return false, err
+		}
+		if secretEqual(again, pass) {
+			return true, nil
+		} else if err := p.echo(again, true); err != nil {
+			return false, err
+		}
+		if !q.once && !q.quietErrors {
+			return false, p.printError(errors.New("passwords do not match"))
+		}
+		return false, nil
+	}
+	return q.Password(ctx, prompt)
+}
+
+// SecretConfirm asks for a secret the user already has and reports whether it
+// matches
+func (p *Prompt) SecretConfirm(ctx context.Context, prompt, against string) (bool, error) {
+	q := newQuestion(p)
+	return q.SecretConfirm(ctx, prompt, against)
+}
+
+// SecretConfirm asks for a secret the user already has, like re-typing an API
+// token, and reports whether it matches. The secrets are compared in constant
+// time. If the secret doesn't match, it's asked for again until MaxAttempts is
+// reached, then false is returned without an error.
+func (q *Question) SecretConfirm(ctx context.Context, prompt, against string) (bool, error) {
+	defer q.withValidator(check(func(s string) error {
+		if !secretEqual(s, against) {
+			return errors.New("secret does not match")
+		}
+		return nil
+	}))()
+
+	secret, err := q.Password(ctx, prompt)
+	if err != nil {
+		// Running out of attempts isn't an error, the secret just didn't match
+		if errors.Is(err, ErrValidation) && !errors.Is(err, ErrClosed) {
+			return false, nil
+		}
+		return false, err
+	}
+	return secretEqual(secret, against), nil
+}
+
+// secretEqual compares secrets in constant time
+func secretEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// MaskSecret masks the middle of a secret for display, like sk-****abcd. The
+// first keepStart and last keepEnd characters are kept. The middle is always
+// replaced by the same number of asterisks, so the secret's length isn't
+// leaked. Secrets that are too short to keep those characters are fully
+// masked.
+func MaskSecret(s string, keepStart, keepEnd int) string {
+	const mask = "****"
+	keepStart, keepEnd = max(keepStart, 0), max(keepEnd, 0)
+	runes := []rune(s)
+	if len(runes) <= keepStart+keepEnd {
+		return mask
+	}
+	return string(runes[:keepStart]) + mask + string(runes[len(runes)-keepEnd:])
+}
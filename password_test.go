@@ -0,0 +1,173 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestNewPassword(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("secret\nsecert\nsecret\nsecret\n")
+	prompt := prompter.New(writer, reader)
+	pass, err := prompt.NewPassword(ctx, "New password:", "Confirm password:")
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+	diff.TestString(t, writer.String(), "New password: \nConfirm password: \npasswords do not match\nNew password: \nConfirm password: \n")
+}
+
+func TestNewPasswordValidate(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("abc\nlonger secret\nlonger secret\n")
+	prompt := prompter.New(writer, reader)
+	pass, err := prompt.Is(prompter.MinLength(8)).NewPassword(ctx, "New password:", "Confirm password:")
+	is.NoErr(err)
+	is.Equal(pass, "longer secret")
+	diff.TestString(t, writer.String(), "New password: \nmust be at least 8 characters, got 3\nNew password: \nConfirm password: \n")
+}
+
+func TestNewPasswordDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	pass, err := prompt.Default("idk").NewPassword(ctx, "New password:", "Confirm password:")
+	is.NoErr(err)
+	is.Equal(pass, "idk")
+}
+
+func TestNewPasswordTypedDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("idk\nidk\n")
+	prompt := prompter.New(writer, reader)
+	pass, err := prompt.Default("idk").NewPassword(ctx, "New password:", "Confirm password:")
+	is.NoErr(err)
+	is.Equal(pass, "idk")
+	// Typing the default still asks for the confirmation
+	diff.TestString(t, writer.String(), "New password: \nConfirm password: \n")
+}
+
+func TestNewPasswordMaxAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("secret\nsecert\nsecret\nsecert\nsecret\nsecret\n")
+	prompt := prompter.New(new(bytes.Buffer), reader)
+	_, err := prompt.MaxAttempts(2).NewPassword(ctx, "New password:", "Confirm password:")
+	is.True(errors.Is(err, prompter.ErrTooManyAttempts))
+	is.True(errors.Is(err, prompter.ErrRejected))
+	// Once gives up after the first mismatch
+	reader = bytes.NewBufferString("secret\nsecert\n")
+	prompt = prompter.New(new(bytes.Buffer), reader)
+	_, err = prompt.Once().NewPassword(ctx, "New password:", "Confirm password:")
+	is.True(errors.Is(err, prompter.ErrRejected))
+}
+
+func TestNewPasswordOnAnswer(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("secret\nsecret2\nsecret\nsecret\n")
+	var values []string
+	prompt := prompter.New(new(bytes.Buffer), reader).OnAnswer(func(prompt, value string, password bool) {
+		values = append(values, prompt+" "+value)
+	})
+	pass, err := prompt.NewPassword(ctx, "New password:", "Confirm password:")
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+	// Only the accepted password is reported, not the confirmations
+	is.Equal(values, []string{"New password: secret"})
+}
+
+func TestNewPasswordOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	pass, err := prompt.Optional(true).NewPassword(ctx, "New password:", "Confirm password:")
+	is.NoErr(err)
+	is.Equal(pass, "")
+}
+
+func TestNewPasswordEOF(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("secret\n")
+	prompt := prompter.New(os.Stdout, reader)
+	pass, err := prompt.NewPassword(ctx, "New password:", "Confirm password:")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(pass, "")
+}
+
+func TestSecretConfirm(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("tok_123\ntok_abc\n")
+	prompt := prompter.New(writer, reader)
+	ok, err := prompt.SecretConfirm(ctx, "Re-enter your token:", "tok_abc")
+	is.NoErr(err)
+	is.True(ok)
+	diff.TestString(t, writer.String(), "Re-enter your token: \nsecret does not match\nRe-enter your token: \n")
+}
+
+func TestSecretConfirmDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("\n"))
+	ok, err := prompt.Default("tok_123").SecretConfirm(ctx, "Re-enter your token:", "tok_abc")
+	is.NoErr(err)
+	is.True(!ok)
+}
+
+func TestSecretConfirmOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("\n"))
+	ok, err := prompt.Optional(true).Once().SecretConfirm(ctx, "Re-enter your token:", "tok_abc")
+	is.NoErr(err)
+	is.True(!ok)
+}
+
+func TestSecretConfirmMaxAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("tok_1\ntok_2\ntok_abc\n")
+	prompt := prompter.New(os.Stdout, reader)
+	ok, err := prompt.MaxAttempts(2).SecretConfirm(ctx, "Re-enter your token:", "tok_abc")
+	is.NoErr(err)
+	is.True(!ok)
+}
+
+func TestSecretConfirmClosed(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	ok, err := prompt.SecretConfirm(ctx, "Re-enter your token:", "tok_abc")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	is.True(!ok)
+}
+
+func TestMaskSecret(t *testing.T) {
+	is := is.New(t)
+	is.Equal(prompter.MaskSecret("sk-1234567890abcd", 3, 4), "sk-****abcd")
+	is.Equal(prompter.MaskSecret("sk-1234567890abcdefghijklmnop", 3, 4), "sk-****mnop")
+	is.Equal(prompter.MaskSecret("secret", 0, 0), "****")
+	is.Equal(prompter.MaskSecret("secret", 0, 2), "****et")
+	is.Equal(prompter.MaskSecret("sécrèt", 2, 1), "sé****t")
+	is.Equal(prompter.MaskSecret("abc", 2, 2), "****")
+	is.Equal(prompter.MaskSecret("abcd", 2, 2), "****")
+	is.Equal(prompter.MaskSecret("", 2, 2), "****")
+	is.Equal(prompter.MaskSecret("secret", -1, -1), "****")
+}
@@ -0,0 +1,35 @@
+package prompter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AskPath asks for a file path and returns it
+func (p *Prompt) AskPath(ctx context.Context, prompt string) (string, error) {
+	q := newQuestion(p)
+	return q.AskPath(ctx, prompt)
+}
+
+// AskPath asks for a file path and returns it. A leading ~ is expanded to the
+// user's home directory. Validators run against the path before it's
+// expanded, so the path validators expand it themselves. An empty string is
+// returned when the question is optional and nothing was entered.
+func (q *Question) AskPath(ctx context.Context, prompt string) (string, error) {
+	return askParse(ctx, q, prompt, expandHome)
+}
+
+// expandHome expands a leading ~ to the user's home directory
+func expandHome(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
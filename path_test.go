@@ -0,0 +1,61 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskPath(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("./bin\n")
+	prompt := prompter.New(os.Stdout, reader)
+	path, err := prompt.AskPath(ctx, "Install to?")
+	is.NoErr(err)
+	is.Equal(path, "./bin")
+}
+
+func TestAskPathHome(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	is.NoErr(os.Mkdir(filepath.Join(home, "bin"), 0755))
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("~/missing\n~/bin\n")
+	prompt := prompter.New(writer, reader)
+	path, err := prompt.Is(prompter.DirExists()).AskPath(ctx, "Install to?")
+	is.NoErr(err)
+	is.Equal(path, filepath.Join(home, "bin"))
+	diff.TestString(t, writer.String(), "Install to? \"~/missing\" does not exist\nInstall to? ")
+}
+
+func TestAskPathDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	path, err := prompt.Default("~").AskPath(ctx, "Install to?")
+	is.NoErr(err)
+	is.Equal(path, home)
+}
+
+func TestAskPathErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	path, err := prompt.AskPath(ctx, "Install to?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(path, "")
+}
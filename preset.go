@@ -0,0 +1,50 @@
+package prompter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AskIfEmpty returns the current value when it's set, otherwise it asks
+func (p *Prompt) AskIfEmpty(ctx context.Context, current, prompt string) (string, error) {
+	q := newQuestion(p)
+	return q.AskIfEmpty(ctx, current, prompt)
+}
+
+// AskIfEmpty returns the current value when it's set, like a flag that was
+// passed in, otherwise it asks. Blank values count as empty. The current
+// value is trimmed, transformed and validated like typed input. If it's
+// invalid, the error is printed and the question is asked as usual, so a bad
+// value can be fixed.
+func (q *Question) AskIfEmpty(ctx context.Context, current, prompt string) (string, error) {
+	if strings.TrimSpace(current) == "" {
+		return q.Ask(ctx, prompt)
+	}
+	value, err := q.preset(current)
+	if err != nil {
+		if !q.quietErrors {
+			if err := q.prompter.printError(fmt.Errorf("invalid %q: %w", current, err)); err != nil {
+				return "", err
+			}
+		}
+		return q.Ask(ctx, prompt)
+	} else if value == "" {
+		return q.Ask(ctx, prompt)
+	}
+	q.prompter.answered(prompt, value, q.hidden || q.mask != 0)
+	return value, nil
+}
+
+// preset trims, transforms and validates a value that was given ahead of
+// time, instead of typed in. An empty value isn't validated.
+func (q *Question) preset(value string) (string, error) {
+	value = q.trimSpace(value)
+	for _, transform := range q.transforms {
+		value = transform(value)
+	}
+	if value == "" {
+		return "", nil
+	}
+	return q.runValidators(value)
+}
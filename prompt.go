@@ -7,7 +7,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"golang.org/x/term"
 )
@@ -15,37 +19,219 @@ import (
 // ErrRequired is returned when a required input is empty
 var ErrRequired = fmt.Errorf("prompter: input is required")
 
+// ErrClosed is returned when the input is closed before a required input is
+// entered, in which case it also matches ErrRequired and io.EOF. It's also
+// returned when the input is closed while the input is still invalid, instead
+// of asking again.
+var ErrClosed = fmt.Errorf("prompter: input closed")
+
+// closedError is returned when the input is closed before a required input is
+// entered
+type closedError struct{}
+
+func (closedError) Error() string {
+	return "prompter: input is required, but the input was closed"
+}
+
+func (closedError) Is(target error) bool {
+	return target == ErrClosed || target == ErrRequired || target == io.EOF
+}
+
+// ErrTimeout is returned when the input isn't entered in time
+var ErrTimeout = fmt.Errorf("prompter: timed out waiting for input")
+
+// ErrInterrupted is returned when Ctrl-C is pressed while the terminal is in
+// raw mode, like when masking a password or editing a line
+var ErrInterrupted = fmt.Errorf("prompter: interrupted")
+
+// ErrTooManyAttempts is returned when the input is still invalid after the
+// maximum number of attempts. It wraps the last validation error or
+// ErrRequired if the last input was empty.
+var ErrTooManyAttempts = fmt.Errorf("prompter: too many attempts")
+
+// ErrValidation matches errors from validators. Any method that asks a
+// question can return it once a question stops retrying, either after
+// MaxAttempts or with Once. The validator's error can be retrieved with
+// errors.Unwrap.
+var ErrValidation = fmt.Errorf("prompter: invalid input")
+
+// validationError wraps an error returned by a validator
+type validationError struct {
+	err error
+}
+
+func (e *validationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *validationError) Unwrap() error {
+	return e.err
+}
+
+func (e *validationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
 // Default creates a default prompt using stdin and stdout
 func Default() *Prompt {
 	return New(os.Stdout, os.Stdin)
 }
 
-// New prompt
+// New prompt. Readers that implement DeadlineReader can be interrupted when a
+// question is cancelled.
 func New(w io.Writer, r io.Reader) *Prompt {
 	fd := getFd(r)
+	deadliner, _ := r.(DeadlineReader)
 	return &Prompt{
-		writer: w,
-		reader: bufio.NewReader(r),
-		fd:     fd,
+		writer:    w,
+		reader:    bufio.NewReader(r),
+		fd:        fd,
+		writerFd:  getFd(w),
+		deadliner: deadliner,
 	}
 }
 
+// PromptWriter sets the writer for the prompts, error messages and anything
+// else written while asking, like the echo of a masked password. This keeps
+// the output clean when answers are written elsewhere, such as writing prompts
+// to stderr and answers to stdout.
+func (p *Prompt) PromptWriter(w io.Writer) *Prompt {
+	p.writer = w
+	p.writerFd = getFd(w)
+	return p
+}
+
+// CancelHint appends a hint on how to cancel to each prompt, like
+// "(Ctrl-C to cancel)". An empty hint removes it.
+func (p *Prompt) CancelHint(hint string) *Prompt {
+	p.cancelHint = hint
+	return p
+}
+
 type fd interface {
 	Fd() uintptr
 }
 
-func getFd(r io.Reader) int {
-	if f, ok := r.(fd); ok {
+type syscallConn interface {
+	SyscallConn() (syscall.RawConn, error)
+}
+
+func getFd(v any) int {
+	// Prefer the raw connection when it's available because calling Fd() puts
+	// the file into blocking mode, which prevents read deadlines from working
+	if sc, ok := v.(syscallConn); ok {
+		if raw, err := sc.SyscallConn(); err == nil {
+			fd := -1
+			if err := raw.Control(func(u uintptr) { fd = int(u) }); err == nil {
+				return fd
+			}
+		}
+	}
+	if f, ok := v.(fd); ok {
 		return int(f.Fd())
 	}
 	return -1
 }
 
+// DeadlineReader is a reader that can interrupt a blocked read, such as an
+// *os.File for a pipe or a net.Conn. When New is given a DeadlineReader,
+// cancelling a question interrupts the read. Plain readers can't be
+// interrupted, so the read is left running until the next input arrives and
+// the next question picks up its result.
+type DeadlineReader interface {
+	io.Reader
+	SetReadDeadline(t time.Time) error
+}
+
 // Prompt can ask for inputs and validate them
 type Prompt struct {
-	writer io.Writer
-	reader *bufio.Reader
-	fd     int
+	writer    io.Writer
+	reader    *bufio.Reader
+	fd        int
+	writerFd  int
+	deadliner DeadlineReader
+	pending   chan result
+	eofs      int
+	afterCR   bool
+	theme     Theme
+	suffix    *string
+	messages  Messages
+
+	lineEditing bool
+	wrap        bool
+	history     *History
+	echoAnswers bool
+	cancelHint  string
+	fallback    Source
+	onAnswer    func(prompt, value string, password bool)
+	// renderPrompt and renderError replace writing prompts and errors
+	renderPrompt func(prompt string) error
+	renderError  func(line string) error
+
+	// rawState is the terminal state to restore while in raw mode
+	rawMu    sync.Mutex
+	rawState *term.State
+}
+
+// readString reads a line ending in \n, \r\n or a lone \r, like the ones sent
+// by some serial terminals. The line ending is included in the line. Invalid
+// UTF-8 is replaced with the replacement character.
+func (p *Prompt) readString() (string, error) {
+	var line []byte
+	for {
+		b, err := p.reader.ReadByte()
+		if err != nil {
+			return validUTF8(string(line)), err
+		}
+		// Skip the \n of a \r\n line ending that arrived after the last line
+		// was read
+		if p.afterCR {
+			p.afterCR = false
+			if b == '\n' && len(line) == 0 {
+				continue
+			}
+		}
+		line = append(line, b)
+		switch b {
+		case '\n':
+			return validUTF8(string(line)), nil
+		case '\r':
+			// Include the \n of a \r\n line ending if it's already arrived. We
+			// can't wait for it because a lone \r may be all that's sent.
+			if p.reader.Buffered() > 0 {
+				if next, err := p.reader.Peek(1); err == nil && next[0] == '\n' {
+					p.reader.ReadByte()
+					return validUTF8(string(line)) + "\n", nil
+				}
+				return validUTF8(string(line)), nil
+			}
+			p.afterCR = true
+			return validUTF8(string(line)), nil
+		}
+	}
+}
+
+// trackEOF counts the number of reads in a row that hit the end of the input
+// without reading anything
+func (p *Prompt) trackEOF(input string, err error) {
+	if input == "" && errors.Is(err, io.EOF) {
+		p.eofs++
+		return
+	}
+	p.eofs = 0
+}
+
+// exhausted is true when the input has ended. A single empty read may come
+// from Ctrl-D on a terminal, which can still be typed into afterwards, so it
+// takes two empty reads in a row.
+func (p *Prompt) exhausted() bool {
+	return p.eofs >= 2
+}
+
+// result of reading the input
+type result struct {
+	input string
+	err   error
 }
 
 // Default sets the default value for the question
@@ -65,7 +251,112 @@ func (p *Prompt) Optional(optional bool) *Question {
 // Is adds validators to the question
 func (p *Prompt) Is(validators ...func(string) error) *Question {
 	q := newQuestion(p)
-	q.validators = append(q.validators, validators...)
+	q.Is(validators...)
+	return q
+}
+
+// IsValue adds validators that can also change the value
+func (p *Prompt) IsValue(validators ...func(string) (string, error)) *Question {
+	q := newQuestion(p)
+	q.IsValue(validators...)
+	return q
+}
+
+// MaxAttempts sets the maximum number of attempts before giving up
+func (p *Prompt) MaxAttempts(n int) *Question {
+	q := newQuestion(p)
+	q.maxAttempts = n
+	return q
+}
+
+// ShowDefault toggles whether the default value is shown in the prompt
+func (p *Prompt) ShowDefault(show bool) *Question {
+	q := newQuestion(p)
+	q.hideDefault = !show
+	return q
+}
+
+// Mask echoes the mask for each character typed into a password
+func (p *Prompt) Mask(mask rune) *Question {
+	q := newQuestion(p)
+	q.mask = mask
+	return q
+}
+
+// Hidden reads the answer without echoing it on a terminal
+func (p *Prompt) Hidden(hidden bool) *Question {
+	q := newQuestion(p)
+	q.hidden = hidden
+	return q
+}
+
+// Transform adds functions that transform the input before it's checked
+func (p *Prompt) Transform(fns ...func(string) string) *Question {
+	q := newQuestion(p)
+	q.transforms = append(q.transforms, fns...)
+	return q
+}
+
+// Timeout sets how long to wait for the input
+func (p *Prompt) Timeout(timeout time.Duration) *Question {
+	q := newQuestion(p)
+	q.timeout = timeout
+	return q
+}
+
+// Once only reads the input once, returning an error instead of asking again
+func (p *Prompt) Once() *Question {
+	q := newQuestion(p)
+	q.once = true
+	return q
+}
+
+// Editable types the default in for the user to edit
+func (p *Prompt) Editable(editable bool) *Question {
+	q := newQuestion(p)
+	q.editable = editable
+	return q
+}
+
+// OnRetry calls fn before the question is asked again
+func (p *Prompt) OnRetry(fn func(attempt int, input string, err error)) *Question {
+	q := newQuestion(p)
+	q.onRetry = fn
+	return q
+}
+
+// QuietErrors stops validation errors from being written before asking again
+func (p *Prompt) QuietErrors(quiet bool) *Question {
+	q := newQuestion(p)
+	q.quietErrors = quiet
+	return q
+}
+
+// AllErrors runs every validator and reports all of their errors together
+func (p *Prompt) AllErrors(all bool) *Question {
+	q := newQuestion(p)
+	q.allErrors = all
+	return q
+}
+
+// Bell rings the terminal bell before asking again after invalid input
+func (p *Prompt) Bell(bell bool) *Question {
+	q := newQuestion(p)
+	q.bell = bell
+	return q
+}
+
+// Progress shows the progress through a series of questions before the prompt
+func (p *Prompt) Progress(current, total int) *Question {
+	q := newQuestion(p)
+	q.current, q.total = current, total
+	return q
+}
+
+// ConfirmOptional makes Confirm use the default when the input is empty
+func (p *Prompt) ConfirmOptional(def bool) *Question {
+	q := newQuestion(p)
+	q.confirmDefault = &def
 	return q
 }
 
@@ -87,62 +378,178 @@ func (p *Prompt) Confirm(ctx context.Context, prompt string) (bool, error) {
 	return q.Confirm(ctx, prompt)
 }
 
+// Askf is like Ask, but formats the prompt with fmt.Sprintf
+func (p *Prompt) Askf(ctx context.Context, format string, args ...any) (string, error) {
+	q := newQuestion(p)
+	return q.Askf(ctx, format, args...)
+}
+
+// Passwordf is like Password, but formats the prompt with fmt.Sprintf
+func (p *Prompt) Passwordf(ctx context.Context, format string, args ...any) (string, error) {
+	q := newQuestion(p)
+	return q.Passwordf(ctx, format, args...)
+}
+
+// Confirmf is like Confirm, but formats the prompt with fmt.Sprintf
+func (p *Prompt) Confirmf(ctx context.Context, format string, args ...any) (bool, error) {
+	q := newQuestion(p)
+	return q.Confirmf(ctx, format, args...)
+}
+
+// ConfirmWith asks for a confirmation using the given yes and no words and
+// returns the input
+func (p *Prompt) ConfirmWith(ctx context.Context, prompt string, yes, no []string) (bool, error) {
+	q := newQuestion(p)
+	return q.ConfirmWith(ctx, prompt, yes, no)
+}
+
+// ConfirmDefault asks for a confirmation, using the default when the input is
+// empty
+func (p *Prompt) ConfirmDefault(ctx context.Context, prompt string, def bool) (bool, error) {
+	q := newQuestion(p)
+	return q.ConfirmDefault(ctx, prompt, def)
+}
+
 func newQuestion(p *Prompt) *Question {
 	return &Question{
 		prompter: p,
 	}
 }
 
+// Clone returns a copy of the question that can be configured and asked
+// without changing the original, so a configured question can be used as a
+// template
+func (q *Question) Clone() *Question {
+	clone := *q
+	clone.validators = slices.Clone(q.validators)
+	clone.transforms = slices.Clone(q.transforms)
+	clone.suggestions = slices.Clone(q.suggestions)
+	if q.confirmDefault != nil {
+		def := *q.confirmDefault
+		clone.confirmDefault = &def
+	}
+	// Reset the state of the last time the question was asked
+	clone.attempts = 0
+	clone.computed = nil
+	clone.usedDefault = false
+	clone.idle = nil
+	clone.budget = nil
+	return &clone
+}
+
 // Question that can be asked
 type Question struct {
-	prompter   *Prompt
-	validators []func(string) error
-	defaultTo  string
-	optional   bool
+	prompter    *Prompt
+	validators  []func(string) (string, error)
+	defaultTo   string
+	optional    bool
+	maxAttempts int
+	hideDefault bool
+	mask        rune
+	reveal      time.Duration
+	hidden      bool
+	transforms  []func(string) string
+	timeout     time.Duration
+	idleTimeout time.Duration
+	// idle is reset on each key press while reading with an idle timeout
+	idle     *idleTimer
+	once     bool
+	editable bool
+	complete func(prefix string) []string
+	// confirmDefault is used by Confirm when the input is empty
+	confirmDefault *bool
+	// attempts is the number of times the question was asked
+	attempts int
+	// budget limits the failed attempts across the questions in a form
+	budget  *attemptBudget
+	onRetry func(attempt int, input string, err error)
+	// quietErrors stops validation errors from being printed
+	quietErrors bool
+	// allErrors runs every validator instead of stopping at the first error
+	allErrors bool
+	bell      bool
+	// duplicateKeys lets keys repeat in AskMap
+	duplicateKeys bool
+	trim          TrimMode
+	suggestions   []string
+	// back is the token that goes back to the previous question in a form
+	back string
+	// key names the question in the fallback source
+	key string
+	// env is the environment variable that answers the question
+	env string
+	// current and total show the progress through a series of questions
+	current int
+	total   int
+	// until accepts or rejects the input in AskUntil
+	until func(input string) (bool, error)
+	// defaultFunc computes the default, which is kept in computed while the
+	// question is being asked
+	defaultFunc func() (string, error)
+	computed    *string
+	// defaultOnBlank uses the default for whitespace-only answers
+	defaultOnBlank bool
+	// usedDefault is true when the last answer was the default
+	usedDefault bool
+	// silent doesn't echo or report the answer, for questions that are part
+	// of another question, like confirming a new password
+	silent bool
 }
 
-func (q *Question) scanLine(inputCh chan<- string, errorCh chan<- error) {
+func (q *Question) scanLine() (string, error) {
 	p := q.prompter
 
+	// Use the line editor on terminals when it's needed
+	if q.editing() {
+		return q.readLine()
+	}
+
 	// Read the input
-	input, err := p.reader.ReadString('\n')
-	if err != nil {
-		if !errors.Is(err, io.EOF) {
-			errorCh <- err
-			return
-		}
-		// If we're at the end of the input, and there is a default, use it,
-		// otherwise return a required error
-		if q.defaultTo != "" {
-			inputCh <- q.defaultTo
-			return
-		} else if !q.optional {
-			errorCh <- ErrRequired
-			return
-		}
+	input, err := p.readString()
+	p.trackEOF(input, err)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
 	}
 
 	// Trim the input
-	input = strings.TrimRight(input, "\r\n")
-	inputCh <- input
+	input = q.trimLineEnding(input)
+	if err != nil {
+		return q.onEOF(input)
+	}
+	return input, nil
+}
+
+// onEOF answers the question once the input has ended, using the fallback
+// answer or the default if there is one. Otherwise a closed error is returned,
+// unless the question is optional, in which case the input is returned as is.
+func (q *Question) onEOF(input string) (string, error) {
+	if answer, ok := q.fallbackAnswer(); ok {
+		return answer, nil
+	} else if q.hasDefault() {
+		return q.defaultValue()
+	} else if !q.optional {
+		return "", closedError{}
+	}
+	return input, nil
 }
 
 // Read the password. If the file descriptor is available, use term.ReadPassword
 // otherwise read the line from the scanner
-func (q *Question) scanPassword(inputCh chan<- string, errorCh chan<- error) {
+func (q *Question) scanPassword() (string, error) {
 	p := q.prompter
 
-	if p.fd > -1 && term.IsTerminal(p.fd) {
+	if p.isTerminal() {
+		if q.mask != 0 {
+			return q.readMasked()
+		}
 		pass, err := term.ReadPassword(p.fd)
 		if err != nil {
-			errorCh <- err
-			return
+			return "", err
 		}
-		inputCh <- string(pass)
-		return
+		return validUTF8(string(pass)), nil
 	}
 
-	q.scanLine(inputCh, errorCh)
+	return q.scanLine()
 }
 
 // Default sets the default value for the question
@@ -159,164 +566,560 @@ func (q *Question) Optional(optional bool) *Question {
 
 // Is adds validators to the question
 func (q *Question) Is(validators ...func(string) error) *Question {
+	for _, validate := range validators {
+		q.validators = append(q.validators, check(validate))
+	}
+	return q
+}
+
+// IsValue adds validators that can also change the value, like lowercasing a
+// domain. Each validator is passed the value returned by the one before it,
+// and the value from the last one is the answer.
+func (q *Question) IsValue(validators ...func(string) (string, error)) *Question {
 	q.validators = append(q.validators, validators...)
 	return q
 }
 
-// Reads the input from the reader
-func (q *Question) readInput(ctx context.Context) (string, error) {
-	// Check if the context has already been cancelled
-	if ctx.Err() != nil {
-		return "", ctx.Err()
-	}
+// MaxAttempts sets the maximum number of attempts before giving up. After n
+// invalid inputs, ErrTooManyAttempts is returned. Zero or less means there's
+// no limit.
+func (q *Question) MaxAttempts(n int) *Question {
+	q.maxAttempts = n
+	return q
+}
 
-	inputCh := make(chan string)
-	errorCh := make(chan error)
+// ShowDefault toggles whether the default value is shown in the prompt. The
+// default value is shown by default, but never for passwords.
+func (q *Question) ShowDefault(show bool) *Question {
+	q.hideDefault = !show
+	return q
+}
 
-	// Scan for the input in a goroutine, so we can listen for cancellations.
-	go q.scanLine(inputCh, errorCh)
+// Mask echoes the mask for each character typed into a password on a
+// terminal. A zero mask doesn't echo anything.
+func (q *Question) Mask(mask rune) *Question {
+	q.mask = mask
+	return q
+}
 
-	// Wait for input, an error or the context to be cancelled
-	select {
-	case input := <-inputCh:
-		close(inputCh)
-		close(errorCh)
-		return input, nil
-	case err := <-errorCh:
-		close(inputCh)
-		close(errorCh)
-		return "", err
-	case <-ctx.Done():
-		// In this case, we're leaking the goroutine that's reading the input.
-		// This is because we can't really cancel reads without limitations.
-		// This seems acceptable because typically when context is canceled, the
-		// process will exit shortly.
-		return "", ctx.Err()
+// Hidden reads the answer without echoing it on a terminal, like a password,
+// for secrets that aren't passwords. The mask is echoed if there is one. Like
+// passwords, the default isn't shown in the prompt. When the input isn't a
+// terminal, the answer is read like any other.
+func (q *Question) Hidden(hidden bool) *Question {
+	q.hidden = hidden
+	return q
+}
+
+// Transform adds functions that transform the input before it's checked. The
+// functions run in the order they were added, after the newline has been
+// trimmed and before the default, optional and validator checks. Validators
+// see the transformed input and the transformed input is what's returned.
+func (q *Question) Transform(fns ...func(string) string) *Question {
+	q.transforms = append(q.transforms, fns...)
+	return q
+}
+
+// Timeout sets how long to wait for the input. When the time runs out, the
+// default is used if there is one, otherwise ErrTimeout is returned. Zero or
+// less means there's no timeout.
+func (q *Question) Timeout(timeout time.Duration) *Question {
+	q.timeout = timeout
+	return q
+}
+
+// Once only reads the input once. Rather than asking again, an invalid input
+// returns an error wrapping ErrValidation and an empty input on a required
+// question returns ErrRequired.
+func (q *Question) Once() *Question {
+	q.once = true
+	return q
+}
+
+// Editable types the default in for the user to edit, instead of showing it
+// after the prompt. This only applies when reading from a terminal, otherwise
+// an empty input uses the default like usual. Clearing the default that was
+// typed in leaves an empty answer, rather than using the default.
+func (q *Question) Editable(editable bool) *Question {
+	q.editable = editable
+	return q
+}
+
+// ConfirmOptional makes Confirm use the default when the input is empty. The
+// prompt is followed by a [Y/n] or [y/N] hint, depending on the default.
+func (q *Question) ConfirmOptional(def bool) *Question {
+	q.confirmDefault = &def
+	return q
+}
+
+// OnRetry calls fn before the question is asked again, after an empty input
+// on a required question or an input that failed validation. fn is called
+// with the failed attempt number, the input, and either ErrRequired or the
+// validator's error. The error is still printed as usual.
+func (q *Question) OnRetry(fn func(attempt int, input string, err error)) *Question {
+	q.onRetry = fn
+	return q
+}
+
+// QuietErrors stops validation errors from being written before asking again,
+// for apps that show errors themselves, such as with OnRetry
+func (q *Question) QuietErrors(quiet bool) *Question {
+	q.quietErrors = quiet
+	return q
+}
+
+// AllErrors runs every validator, rather than stopping at the first one that
+// fails, so all of the problems can be fixed at once, like the rules of a
+// password. The errors are joined with errors.Join and written one per line.
+func (q *Question) AllErrors(all bool) *Question {
+	q.allErrors = all
+	return q
+}
+
+// Bell rings the terminal bell before asking again after invalid input, as an
+// audible cue alongside the error. The bell is only rung when writing to a
+// terminal.
+func (q *Question) Bell(bell bool) *Question {
+	q.bell = bell
+	return q
+}
+
+// Progress shows the progress through a series of questions before the
+// prompt, like "(2/5) What is your name?". Nothing is shown when current or
+// total is zero.
+func (q *Question) Progress(current, total int) *Question {
+	q.current, q.total = current, total
+	return q
+}
+
+// Attempts returns the number of times the question was asked the last time
+// it was answered, including the final attempt. Attempts are counted for both
+// empty required inputs and inputs that failed validation.
+func (q *Question) Attempts() int {
+	return q.attempts
+}
+
+// Reads the input from the reader
+func (q *Question) readInput(ctx context.Context) (string, error) {
+	if q.hidden && q.prompter.isTerminal() {
+		return q.readPassword(ctx)
 	}
+	return q.readAsync(ctx, q.scanLine)
 }
 
 // Reads the password from the reader
 func (q *Question) readPassword(ctx context.Context) (string, error) {
+	p := q.prompter
+	pass, err := q.readAsync(ctx, q.scanPassword)
+	if err != nil {
+		return "", err
+	}
+	// Print a newline after the password, unless it's echoed
+	if !p.echoing() {
+		if err := p.render("\n"); err != nil {
+			return "", err
+		}
+	}
+	return pass, nil
+}
+
+// readAsync scans in a goroutine, so we can listen for cancellations
+func (q *Question) readAsync(ctx context.Context, scan func() (string, error)) (string, error) {
+	p := q.prompter
+
 	// Check if the context has already been cancelled
 	if ctx.Err() != nil {
 		return "", ctx.Err()
 	}
 
-	inputCh := make(chan string)
-	errorCh := make(chan error)
-
-	// Scan for the password in a goroutine, so we can listen for cancelations.
-	go q.scanPassword(inputCh, errorCh)
+	// If a previous read was abandoned, pick up its result instead of starting
+	// a new read, so that input isn't lost. Otherwise scan in a goroutine. The
+	// channel is buffered so the goroutine can always send its result and exit,
+	// even after we've stopped listening.
+	resultCh := p.pending
+	p.pending = nil
+	if resultCh == nil {
+		resultCh = make(chan result, 1)
+		go func() {
+			input, err := scan()
+			resultCh <- result{input, err}
+		}()
+	}
 
 	// Wait for input, an error or the context to be cancelled
 	select {
-	case input := <-inputCh:
-		close(inputCh)
-		close(errorCh)
-		return input, nil
-	case err := <-errorCh:
-		close(inputCh)
-		close(errorCh)
-		return "", err
+	case result := <-resultCh:
+		return result.input, result.err
 	case <-ctx.Done():
-		// In this case, we're leaking the goroutine that's reading the password.
-		// This is because we can't really cancel reads without limitations.
-		// This seems acceptable because typically when context is canceled, the
-		// process will exit shortly.
+		// If the reader supports deadlines, interrupt the read and wait for the
+		// goroutine to finish. Then clear the deadline so the reader can be used
+		// again.
+		if p.deadliner != nil && p.deadliner.SetReadDeadline(time.Now()) == nil {
+			<-resultCh
+			p.deadliner.SetReadDeadline(time.Time{})
+			return "", ctx.Err()
+		}
+		// Otherwise, the goroutine is left blocked on the read until the next
+		// input arrives or the reader is closed, since plain readers can't be
+		// interrupted. The next read picks up where this one left off. Don't
+		// leave the terminal in raw mode while we wait.
+		p.pending = resultCh
+		p.restoreTerminal()
 		return "", ctx.Err()
 	}
 }
 
 // Ask asks a question and returns the input
 func (q *Question) Ask(ctx context.Context, prompt string) (string, error) {
-	p := q.prompter
+	answer, err := q.AskResult(ctx, prompt)
+	return answer.Value, err
+}
 
-	// Write out the formatted prompt
-retry:
-	fmt.Fprint(p.writer, prompt, " ")
+// Askf is like Ask, but formats the prompt with fmt.Sprintf
+func (q *Question) Askf(ctx context.Context, format string, args ...any) (string, error) {
+	return q.Ask(ctx, fmt.Sprintf(format, args...))
+}
 
-	// Read the input
-	input, err := q.readInput(ctx)
-	if err != nil {
-		return "", err
+// Passwordf is like Password, but formats the prompt with fmt.Sprintf
+func (q *Question) Passwordf(ctx context.Context, format string, args ...any) (string, error) {
+	return q.Password(ctx, fmt.Sprintf(format, args...))
+}
+
+// Confirmf is like Confirm, but formats the prompt with fmt.Sprintf
+func (q *Question) Confirmf(ctx context.Context, format string, args ...any) (bool, error) {
+	return q.Confirm(ctx, fmt.Sprintf(format, args...))
+}
+
+// Password asks for a password and returns the input
+func (q *Question) Password(ctx context.Context, prompt string) (string, error) {
+	return q.ask(ctx, prompt, true, q.readPassword)
+}
+
+// format the prompt, adding a hint for the default value
+func (q *Question) format(prompt string, password bool) string {
+	p := q.prompter
+	if q.current > 0 && q.total > 0 {
+		prompt = fmt.Sprintf("(%d/%d) %s", q.current, q.total, prompt)
+	}
+	if len(q.suggestions) > 0 {
+		prompt += " (" + strings.Join(q.suggestions, ", ") + ")"
+	}
+	// The default doesn't need to be shown when it's already typed in
+	if q.defaultTo != "" && !q.hideDefault && !password && !q.editing() {
+		prompt += " " + p.formatDefault(q.defaultTo)
+	}
+	if p.cancelHint != "" {
+		prompt += " " + p.cancelHint
 	}
+	// Leave room for the suffix so the cursor stays on the last line
+	if width := p.wrapWidth(); width > 0 {
+		suffix := runesWidth([]rune(p.promptSuffix()))
+		prompt = wrapText(prompt, max(width-suffix, 1))
+	}
+	return p.colorPrompt(prompt) + p.promptSuffix()
+}
 
-	// If the input is empty, and there is a default, use it otherwise ask again
-	if input == "" {
-		if q.defaultTo != "" {
-			return q.defaultTo, nil
-		} else if !q.optional {
-			goto retry
+// readTimeout reads the input, giving up once the timeout or idle timeout has
+// passed. If there's a default, it's used instead.
+func (q *Question) readTimeout(ctx context.Context, read func(context.Context) (string, error)) (string, error) {
+	if q.timeout <= 0 && q.idleTimeout <= 0 {
+		return read(ctx)
+	}
+	p := q.prompter
+	readCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	if q.timeout > 0 {
+		timer := time.AfterFunc(q.timeout, func() { cancel(ErrTimeout) })
+		defer timer.Stop()
+	}
+	q.idle = nil
+	if q.idleTimeout > 0 {
+		q.idle = newIdleTimer(q.idleTimeout, func() { cancel(ErrTimeout) })
+		defer q.idle.stop()
+	}
+	input, err := read(readCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(readCtx), ErrTimeout) {
+		// Move past the unanswered prompt
+		if err := p.render("\n"); err != nil {
+			return "", err
 		}
+		// An empty input falls back to the default
+		if q.hasDefault() {
+			return "", nil
+		}
+		return "", ErrTimeout
 	}
+	return input, err
+}
 
-	// If any validators fail, print the error and ask again
-	for _, validate := range q.validators {
-		if err := validate(input); err != nil {
-			fmt.Fprintln(p.writer, err)
-			goto retry
-		}
+// writePrompt writes the formatted prompt. Writing fails when the output has
+// gone away, like a closed pipe, so the error is returned rather than asking
+// questions nobody can see. Prompts go to the renderer instead, if there is
+// one.
+func (q *Question) writePrompt(prompt string, password bool) error {
+	p := q.prompter
+	if p.renderPrompt != nil {
+		return p.renderPrompt(q.format(prompt, password))
+	}
+	if _, err := fmt.Fprint(p.writer, q.format(prompt, password)); err != nil {
+		return fmt.Errorf("prompter: unable to write the prompt: %w", err)
 	}
+	return nil
+}
 
-	return input, nil
+// cancelled moves past the unanswered prompt when the context is cancelled,
+// so the terminal isn't left mid-line. The read error is returned, unless
+// moving past the prompt fails.
+func (q *Question) cancelled(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		if err := q.prompter.render("\n"); err != nil {
+			return err
+		}
+	}
+	return err
 }
 
-// Password asks for a password and returns the input
-func (q *Question) Password(ctx context.Context, prompt string) (string, error) {
+// ask writes the prompt, reads the input and validates it. If the input is
+// invalid, the question is asked again until it's valid or the maximum number
+// of attempts has been reached.
+func (q *Question) ask(ctx context.Context, prompt string, password bool, read func(context.Context) (string, error)) (string, error) {
 	p := q.prompter
+	q.attempts = 0
+	q.computed = nil
+	q.usedDefault = false
+
+	// Use the environment variable's value without asking, if it's valid
+	if answer, ok, err := q.envAnswer(); err != nil {
+		return "", err
+	} else if ok {
+		p.answered(prompt, answer, password)
+		return answer, nil
+	}
 
 	// Write out the formatted prompt
 retry:
-	fmt.Fprint(p.writer, prompt, " ")
+	q.attempts++
+	if err := q.writePrompt(prompt, password); err != nil {
+		return "", err
+	}
 
 	// Read the input
-	pass, err := q.readPassword(ctx)
+	input, err := q.readTimeout(ctx, read)
+	cleared := errors.Is(err, errCleared)
+	if err != nil && !cleared {
+		return "", q.cancelled(ctx, err)
+	}
+
+	// Trim and transform the input before it's checked
+	input = q.trimSpace(input)
+	if q.back != "" && input == q.back {
+		return "", errBack
+	}
+	for _, transform := range q.transforms {
+		input = transform(input)
+	}
+
+	// If the input is empty, and there is a default, use it otherwise ask again.
+	// A default that was typed in and then cleared isn't used. Defaults skip
+	// the validators and empty inputs only reach them when the question is
+	// optional, so methods that parse the answer check it again afterwards.
+	if input == "" {
+		defaultTo := ""
+		if !cleared {
+			if defaultTo, err = q.defaultValue(); err != nil {
+				return "", err
+			}
+		}
+		if defaultTo != "" {
+			return q.accept(prompt, defaultTo, password)
+		} else if !q.optional {
+			required := p.errRequired()
+			if err := q.giveUp(required); err != nil {
+				return "", err
+			}
+			q.retry(input, required)
+			goto retry
+		}
+	}
+
+	// If any validators fail, print the error and ask again. When only asking
+	// once, the error is returned instead.
+	input, err = q.runValidators(input)
 	if err != nil {
+		if !q.once && !q.quietErrors {
+			if err := p.printError(err); err != nil {
+				return "", err
+			}
+		}
+		if err := q.giveUp(&validationError{err}); err != nil {
+			return "", err
+		}
+		q.retry(input, err)
+		goto retry
+	}
+
+	// Ask again if the AskUntil check rejects the input
+	if ok, err := q.checkUntil(input); err != nil {
 		return "", err
+	} else if !ok {
+		if err := q.giveUp(ErrRejected); err != nil {
+			return "", err
+		}
+		q.retry(input, ErrRejected)
+		goto retry
 	}
-	// Print a newline after the password
-	fmt.Fprintln(p.writer)
 
-	if pass == "" {
-		if q.defaultTo != "" {
-			return q.defaultTo, nil
+	return q.accept(prompt, input, password)
+}
+
+// Validate checks a value the same way an answer is checked, without asking
+// for it. The value is trimmed and transformed, then ErrRequired is returned
+// if it's empty and the question is required. Otherwise the first validator
+// error is returned, wrapped so it matches ErrValidation. Like answers, an
+// empty value is valid when there is a default.
+func (q *Question) Validate(s string) error {
+	s = q.trimSpace(s)
+	for _, transform := range q.transforms {
+		s = transform(s)
+	}
+	if s == "" {
+		if q.hasDefault() {
+			return nil
 		} else if !q.optional {
-			goto retry
+			return q.prompter.errRequired()
 		}
 	}
+	if _, err := q.runValidators(s); err != nil {
+		return &validationError{err}
+	}
+	return nil
+}
 
-	// If any validators fail, print the error and ask again
+// runValidators threads the input through the validators, returning the
+// value from the last one or the first error
+func (q *Question) runValidators(input string) (string, error) {
+	if !q.allErrors {
+		return runValidators(q.validators, input)
+	}
+	// Keep going after an error, passing on the value from before it
+	var errs []error
 	for _, validate := range q.validators {
-		if err := validate(pass); err != nil {
-			fmt.Fprintln(p.writer, err)
-			goto retry
+		value, err := validate(input)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
+		input = value
 	}
+	return input, errors.Join(errs...)
+}
 
-	return pass, nil
+// runValidators threads the input through the validators
+func runValidators(validators []func(string) (string, error), input string) (string, error) {
+	for _, validate := range validators {
+		value, err := validate(input)
+		if err != nil {
+			return input, err
+		}
+		input = value
+	}
+	return input, nil
 }
 
-func isYes(s string) bool {
-	switch strings.ToLower(s) {
-	case "y", "yes", "true":
-		return true
+// withValidator adds a validator while the question is being asked. The
+// returned function removes it again, so asking doesn't change the question.
+// The validators are clipped so the array isn't shared with clones.
+func (q *Question) withValidator(validate func(string) (string, error)) (remove func()) {
+	validators := q.validators
+	q.validators = append(slices.Clip(validators), validate)
+	return func() { q.validators = validators }
+}
+
+// check turns a validator into one that leaves the value unchanged
+func check(validate func(string) error) func(string) (string, error) {
+	return func(s string) (string, error) {
+		return s, validate(s)
 	}
-	return false
 }
 
-// Confirm asks for a confirmation and returns the input
+// retry calls the retry callback and rings the bell before the question is
+// asked again
+func (q *Question) retry(input string, err error) {
+	if q.onRetry != nil {
+		q.onRetry(q.attempts, input, err)
+	}
+	// Only ring the bell on terminals, so it doesn't end up in logs
+	if q.bell && q.prompter.writesTerminal() {
+		fmt.Fprint(q.prompter.writer, "\a")
+	}
+}
+
+// giveUp returns an error if the question shouldn't be asked again after a
+// failed attempt
+func (q *Question) giveUp(err error) error {
+	p := q.prompter
+	if p.exhausted() {
+		// Asking again won't help once the input has ended
+		return fmt.Errorf("%w: %w", ErrClosed, err)
+	} else if q.once {
+		return err
+	} else if q.maxAttempts > 0 && q.attempts >= q.maxAttempts {
+		return fmt.Errorf("%w: %w", ErrTooManyAttempts, err)
+	} else if q.budget != nil && q.budget.spend() {
+		return fmt.Errorf("%w: %w", ErrFormAttempts, err)
+	}
+	return nil
+}
+
+// yesWords and noWords are the answers Confirm accepts, ignoring case. The
+// validator and the interpretation both use them, so they can't disagree.
+var (
+	yesWords = []string{"y", "yes", "true", "1"}
+	noWords  = []string{"n", "no", "false", "0"}
+)
+
+// parseConfirm interprets a confirmation, returning false for ok if it's
+// neither yes nor no
+func parseConfirm(s string) (yes bool, ok bool) {
+	switch {
+	case containsFold(yesWords, s):
+		return true, true
+	case containsFold(noWords, s):
+		return false, true
+	}
+	return false, false
+}
+
+func isYes(s string) bool {
+	yes, _ := parseConfirm(s)
+	return yes
+}
+
+// Confirm asks for a confirmation and returns the input. Yes can be entered
+// as y, yes, true or 1 and no as n, no, false or 0, ignoring case.
 func (q *Question) Confirm(ctx context.Context, prompt string) (bool, error) {
+	// Restore the default afterwards, so the question can be asked again
+	defaultTo, hideDefault := q.defaultTo, q.hideDefault
+	defer func() { q.defaultTo, q.hideDefault = defaultTo, hideDefault }()
+
+	// Use the confirm default for empty inputs, which skips the validators
+	if q.confirmDefault != nil {
+		hint, defaultTo := "[y/N]", "no"
+		if *q.confirmDefault {
+			hint, defaultTo = "[Y/n]", "yes"
+		}
+		q.defaultTo = defaultTo
+		q.hideDefault = true
+		prompt += " " + hint
+	}
+
 	// Add a validator to ensure the input is yes or no
-	q.validators = append(q.validators, func(s string) error {
-		switch strings.ToLower(s) {
-		case "y", "yes":
-			return nil
-		case "n", "no":
-			return nil
-		default:
-			return fmt.Errorf("invalid value %q, must enter yes or no", s)
+	defer q.withValidator(check(func(s string) error {
+		if _, ok := parseConfirm(s); !ok {
+			return q.prompter.errConfirmInvalid(s)
 		}
-	})
+		return nil
+	}))()
 
 	input, err := q.Ask(ctx, prompt)
 	if err != nil {
@@ -325,3 +1128,43 @@ func (q *Question) Confirm(ctx context.Context, prompt string) (bool, error) {
 
 	return isYes(input), nil
 }
+
+// ConfirmDefault asks for a confirmation, using the default when the input is
+// empty. The prompt is followed by a [Y/n] or [y/N] hint, depending on the
+// default.
+func (q *Question) ConfirmDefault(ctx context.Context, prompt string, def bool) (bool, error) {
+	// Restore the confirm default afterwards, so the question can be asked again
+	confirmDefault := q.confirmDefault
+	defer func() { q.confirmDefault = confirmDefault }()
+	return q.ConfirmOptional(def).Confirm(ctx, prompt)
+}
+
+// ConfirmWith asks for a confirmation using the given yes and no words and
+// returns the input. Words are matched case-insensitively.
+func (q *Question) ConfirmWith(ctx context.Context, prompt string, yes, no []string) (bool, error) {
+	// Add a validator to ensure the input is one of the yes or no words
+	defer q.withValidator(check(func(s string) error {
+		if containsFold(yes, s) || containsFold(no, s) {
+			return nil
+		}
+		words := append(append([]string{}, yes...), no...)
+		return q.prompter.errConfirmWithInvalid(s, words)
+	}))()
+
+	input, err := q.Ask(ctx, prompt)
+	if err != nil {
+		return false, err
+	}
+
+	return containsFold(yes, input), nil
+}
+
+// containsFold checks if the word is in the list, ignoring case
+func containsFold(words []string, word string) bool {
+	for _, w := range words {
+		if strings.EqualFold(w, word) {
+			return true
+		}
+	}
+	return false
+}
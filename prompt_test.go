@@ -5,8 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
+	"runtime"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/matryer/is"
 	"github.com/matthewmueller/diff"
@@ -190,6 +197,20 @@ func TestConfirmFalse(t *testing.T) {
 	is.Equal(create, false)
 }
 
+func TestConfirmWords(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("true\n1\nFALSE\n0\nmaybe\n2\nY\n")
+	prompt := prompter.New(writer, reader)
+	for _, want := range []bool{true, true, false, false, true} {
+		ok, err := prompt.Confirm(ctx, "Continue?")
+		is.NoErr(err)
+		is.Equal(ok, want)
+	}
+	diff.TestString(t, writer.String(), "Continue? Continue? Continue? Continue? Continue? invalid value \"maybe\", must enter yes or no\nContinue? invalid value \"2\", must enter yes or no\nContinue? ")
+}
+
 func TestAskCancel(t *testing.T) {
 	is := is.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -223,3 +244,751 @@ func TestConfirmCancel(t *testing.T) {
 	_, err := prompt.Confirm(ctx, "Create new user? (yes/no)")
 	is.True(errors.Is(err, context.Canceled))
 }
+
+func TestAskMaxAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("A\nAm\nAmy\n")
+	prompt := prompter.New(writer, reader)
+	errTooShort := errors.New("too short")
+	validName := func(s string) error {
+		if len(s) < 3 {
+			return errTooShort
+		}
+		return nil
+	}
+	name, err := prompt.MaxAttempts(2).Is(validName).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrTooManyAttempts))
+	is.True(errors.Is(err, prompter.ErrValidation))
+	is.True(errors.Is(err, errTooShort))
+	is.Equal(name, "")
+	diff.TestString(t, writer.String(), "What is your name? too short\nWhat is your name? too short\n")
+}
+
+func TestAskMaxAttemptsRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n\nMark\n")
+	prompt := prompter.New(os.Stdout, reader)
+	name, err := prompt.MaxAttempts(2).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrTooManyAttempts))
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.True(!errors.Is(err, prompter.ErrValidation))
+	is.Equal(name, "")
+}
+
+func TestAskMaxAttemptsReset(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\nMark\n\nAmy\n")
+	prompt := prompter.New(os.Stdout, reader)
+	question := prompt.MaxAttempts(2)
+	name, err := question.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Mark")
+	name, err = question.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Amy")
+}
+
+func TestPasswordMaxAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("mypassword\nsome password\n")
+	prompt := prompter.New(os.Stdout, reader)
+	validate := func(s string) error {
+		if s != "some password" {
+			return errors.New("invalid password")
+		}
+		return nil
+	}
+	pass, err := prompt.MaxAttempts(1).Is(validate).Password(ctx, "What is your password?")
+	is.True(errors.Is(err, prompter.ErrTooManyAttempts))
+	is.Equal(pass, "")
+}
+
+func TestAskShowDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n\n")
+	prompt := prompter.New(writer, reader)
+	age, err := prompt.Default("21").Ask(ctx, "What is your age?")
+	is.NoErr(err)
+	is.Equal(age, "21")
+	age, err = prompt.Default("21").ShowDefault(false).Ask(ctx, "What is your age?")
+	is.NoErr(err)
+	is.Equal(age, "21")
+	diff.TestString(t, writer.String(), "What is your age? [21] What is your age? ")
+}
+
+func TestPasswordHideDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(writer, reader)
+	pass, err := prompt.Default("idk").Password(ctx, "What is your password?")
+	is.NoErr(err)
+	is.Equal(pass, "idk")
+	diff.TestString(t, writer.String(), "What is your password? \n")
+}
+
+func TestConfirmDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n\nno\n")
+	prompt := prompter.New(writer, reader)
+	remove, err := prompt.ConfirmDefault(ctx, "Delete everything?", false)
+	is.NoErr(err)
+	is.Equal(remove, false)
+	create, err := prompt.ConfirmDefault(ctx, "Create new user?", true)
+	is.NoErr(err)
+	is.Equal(create, true)
+	create, err = prompt.ConfirmDefault(ctx, "Create new user?", true)
+	is.NoErr(err)
+	is.Equal(create, false)
+	diff.TestString(t, writer.String(), "Delete everything? [y/N] Create new user? [Y/n] Create new user? [Y/n] ")
+}
+
+func TestConfirmOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nmaybe\n\n")
+	prompt := prompter.New(writer, reader)
+	create, err := prompt.ConfirmOptional(true).Confirm(ctx, "Create new user?")
+	is.NoErr(err)
+	is.Equal(create, true)
+	remove, err := prompt.ConfirmOptional(false).Confirm(ctx, "Delete everything?")
+	is.NoErr(err)
+	is.Equal(remove, false)
+	diff.TestString(t, writer.String(), "Create new user? [Y/n] Delete everything? [y/N] invalid value \"maybe\", must enter yes or no\nDelete everything? [y/N] ")
+}
+
+func TestConfirmWith(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("yes\nOUI\nn\n")
+	prompt := prompter.New(writer, reader)
+	yes, no := []string{"oui", "o"}, []string{"non", "n"}
+	create, err := prompt.ConfirmWith(ctx, "Créer un utilisateur ?", yes, no)
+	is.NoErr(err)
+	is.Equal(create, true)
+	create, err = prompt.ConfirmWith(ctx, "Créer un utilisateur ?", yes, no)
+	is.NoErr(err)
+	is.Equal(create, false)
+	diff.TestString(t, writer.String(), "Créer un utilisateur ? invalid value \"yes\", must enter one of oui, o, non, n\nCréer un utilisateur ? Créer un utilisateur ? ")
+}
+
+func TestAskCancelReclaimsGoroutine(t *testing.T) {
+	is := is.New(t)
+	r, w, err := os.Pipe()
+	is.NoErr(err)
+	defer r.Close()
+	defer w.Close()
+	prompt := prompter.New(io.Discard, r)
+	baseline := runtime.NumGoroutine()
+	for i := 0; i < 50; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		_, err := prompt.Ask(ctx, "What is your name?")
+		cancel()
+		is.True(errors.Is(err, context.DeadlineExceeded))
+	}
+	is.True(runtime.NumGoroutine() <= baseline)
+	// The prompt can still be used after cancelling
+	_, err = w.WriteString("Mark\n")
+	is.NoErr(err)
+	name, err := prompt.Ask(context.Background(), "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Mark")
+}
+
+func TestAskCancelDeadlineReader(t *testing.T) {
+	is := is.New(t)
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	var reader prompter.DeadlineReader = client
+	prompt := prompter.New(io.Discard, reader)
+	baseline := runtime.NumGoroutine()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := prompt.Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	// The read was interrupted rather than left running
+	is.True(runtime.NumGoroutine() <= baseline)
+	go server.Write([]byte("Mark\n"))
+	name, err := prompt.Ask(context.Background(), "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Mark")
+}
+
+func TestAskTransform(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("  \nMARK@Example.com \n")
+	prompt := prompter.New(writer, reader)
+	validEmail := func(s string) error {
+		if s != strings.ToLower(s) {
+			return errors.New("must be lowercase")
+		}
+		return nil
+	}
+	email, err := prompt.Transform(strings.TrimSpace, strings.ToLower).Is(validEmail).Ask(ctx, "What is your email?")
+	is.NoErr(err)
+	is.Equal(email, "mark@example.com")
+	diff.TestString(t, writer.String(), "What is your email? What is your email? ")
+}
+
+func TestAskTimeout(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	r, w := io.Pipe()
+	defer w.Close()
+	writer := new(bytes.Buffer)
+	prompt := prompter.New(writer, r)
+	age, err := prompt.Timeout(10*time.Millisecond).Ask(ctx, "What is your age?")
+	is.True(errors.Is(err, prompter.ErrTimeout))
+	is.Equal(age, "")
+	diff.TestString(t, writer.String(), "What is your age? \n")
+}
+
+func TestAskTimeoutDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	r, w := io.Pipe()
+	defer w.Close()
+	prompt := prompter.New(io.Discard, r)
+	age, err := prompt.Default("21").Timeout(10*time.Millisecond).Ask(ctx, "What is your age?")
+	is.NoErr(err)
+	is.Equal(age, "21")
+	// Input typed after the timeout isn't lost
+	go w.Write([]byte("Mark\n"))
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Mark")
+}
+
+func TestAskTimeoutCancel(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reader := bytes.NewBufferString("Mark\n")
+	prompt := prompter.New(io.Discard, reader)
+	name, err := prompt.Default("Amy").Timeout(time.Second).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, context.Canceled))
+	is.Equal(name, "")
+}
+
+func TestAskErrValidationUnwrap(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Am\n")
+	prompt := prompter.New(io.Discard, reader)
+	errTooShort := errors.New("too short")
+	validName := func(s string) error {
+		return errTooShort
+	}
+	_, err := prompt.MaxAttempts(1).Is(validName).Ask(ctx, "What is your name?")
+	var validationErr interface{ Unwrap() []error }
+	is.True(errors.As(err, &validationErr))
+	errs := validationErr.Unwrap()
+	is.Equal(len(errs), 2)
+	is.True(errors.Is(errs[1], prompter.ErrValidation))
+	is.Equal(errors.Unwrap(errs[1]), errTooShort)
+	is.Equal(err.Error(), "prompter: too many attempts: too short")
+}
+
+func TestAskOnce(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Am\nAmy\n")
+	prompt := prompter.New(writer, reader)
+	name, err := prompt.Once().Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrValidation))
+	is.Equal(err.Error(), "must be at least 3 characters, got 2")
+	is.Equal(name, "")
+	diff.TestString(t, writer.String(), "What is your name? ")
+}
+
+func TestAskOnceRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\nMark\n")
+	prompt := prompter.New(io.Discard, reader)
+	name, err := prompt.Once().Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(name, "")
+}
+
+func TestConfirmOnce(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("hello\nyes\n")
+	prompt := prompter.New(io.Discard, reader)
+	create, err := prompt.Once().Confirm(ctx, "Create new user? (yes/no)")
+	is.True(errors.Is(err, prompter.ErrValidation))
+	is.Equal(create, false)
+}
+
+func TestAskErrClosed(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(io.Discard, reader)
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.True(errors.Is(err, io.EOF))
+	is.Equal(name, "")
+}
+
+func TestAskOnceEmptyNotClosed(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(io.Discard, reader)
+	name, err := prompt.Once().Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.True(!errors.Is(err, prompter.ErrClosed))
+	is.Equal(name, "")
+}
+
+func TestAskClosedOptionalInvalid(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(io.Discard, reader)
+	nonEmpty := func(s string) error {
+		if s == "" {
+			return errors.New("must not be empty")
+		}
+		return nil
+	}
+	name, err := prompt.Optional(true).Is(nonEmpty).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	is.True(errors.Is(err, prompter.ErrValidation))
+	is.Equal(name, "")
+}
+
+func TestAskClosedInvalidDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(io.Discard, reader)
+	name, err := prompt.Default("Al").Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	is.Equal(name, "")
+}
+
+func TestConfirmClosed(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(io.Discard, reader)
+	create, err := prompt.Optional(true).Confirm(ctx, "Create new user? (yes/no)")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	is.Equal(create, false)
+}
+
+func TestValidate(t *testing.T) {
+	is := is.New(t)
+	prompt := prompter.New(io.Discard, bytes.NewBufferString(""))
+	question := prompt.Is(prompter.MinLength(3), prompter.MaxLength(5))
+	is.NoErr(question.Validate("Alice"))
+	err := question.Validate("Al")
+	is.True(errors.Is(err, prompter.ErrValidation))
+	is.Equal(err.Error(), "must be at least 3 characters, got 2")
+	is.True(errors.Is(question.Validate(""), prompter.ErrRequired))
+	is.NoErr(prompt.Optional(true).Validate(""))
+}
+
+func TestValidateTransform(t *testing.T) {
+	is := is.New(t)
+	prompt := prompter.New(io.Discard, bytes.NewBufferString(""))
+	question := prompt.Transform(strings.TrimSpace).Is(prompter.OneOf("dev", "prod"))
+	is.NoErr(question.Validate("  prod "))
+	is.True(errors.Is(question.Validate("   "), prompter.ErrRequired))
+	is.NoErr(question.Default("dev").Validate(""))
+}
+
+func TestAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\nAl\nAlice\nBob\n")
+	prompt := prompter.New(io.Discard, reader)
+	question := prompt.Is(prompter.MinLength(3))
+	is.Equal(question.Attempts(), 0)
+	name, err := question.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	is.Equal(question.Attempts(), 3)
+	name, err = question.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Bob")
+	is.Equal(question.Attempts(), 1)
+}
+
+func TestOnRetry(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nAl\nAlice\n")
+	prompt := prompter.New(writer, reader)
+	var retries []string
+	onRetry := func(attempt int, input string, err error) {
+		// Called before the prompt is written again
+		is.Equal(strings.Count(writer.String(), "What is your name?"), attempt)
+		retries = append(retries, fmt.Sprintf("%d %q %v", attempt, input, err))
+	}
+	name, err := prompt.OnRetry(onRetry).Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	is.Equal(retries, []string{
+		`1 "" prompter: input is required`,
+		`2 "Al" must be at least 3 characters, got 2`,
+	})
+}
+
+func TestOnRetryGiveUp(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Al\nAl\n")
+	prompt := prompter.New(io.Discard, reader)
+	retries := 0
+	onRetry := func(attempt int, input string, err error) {
+		retries++
+	}
+	name, err := prompt.OnRetry(onRetry).MaxAttempts(2).Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrTooManyAttempts))
+	is.Equal(name, "")
+	is.Equal(retries, 1)
+}
+
+func TestQuietErrors(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Al\nAlice\n")
+	prompt := prompter.New(writer, reader)
+	var retryErr error
+	onRetry := func(attempt int, input string, err error) {
+		retryErr = err
+	}
+	name, err := prompt.QuietErrors(true).OnRetry(onRetry).Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	is.Equal(retryErr.Error(), "must be at least 3 characters, got 2")
+	diff.TestString(t, writer.String(), "What is your name? What is your name? ")
+}
+
+func TestPromptWriter(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Al\nAlice\n")
+	prompt := prompter.New(stdout, reader).PromptWriter(stderr)
+	name, err := prompt.Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	fmt.Fprintln(stdout, name)
+	diff.TestString(t, stderr.String(), "What is your name? must be at least 3 characters, got 2\nWhat is your name? ")
+	diff.TestString(t, stdout.String(), "Alice\n")
+}
+
+func TestAskLineEndings(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Alice\rBob\nCarol\r\nDave\r")
+	prompt := prompter.New(io.Discard, reader)
+	for _, expect := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		name, err := prompt.Ask(ctx, "What is your name?")
+		is.NoErr(err)
+		is.Equal(name, expect)
+	}
+	_, err := prompt.Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+}
+
+func TestAskLoneCarriageReturn(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	// The \n of the \r\n arrives after the line has been read
+	reader, writer := io.Pipe()
+	prompt := prompter.New(io.Discard, reader)
+	go writer.Write([]byte("Alice\r"))
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	go writer.Write([]byte("\nBob\r\n"))
+	name, err = prompt.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Bob")
+	go writer.Write([]byte("\r"))
+	name, err = prompt.Optional(true).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "")
+}
+
+func TestAskf(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("8080\nsecret\nyes\n")
+	prompt := prompter.New(writer, reader)
+	port, err := prompt.Askf(ctx, "Enter value for %s:", "PORT")
+	is.NoErr(err)
+	is.Equal(port, "8080")
+	pass, err := prompt.Passwordf(ctx, "Password for %s:", "alice")
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+	ok, err := prompt.Default("no").Confirmf(ctx, "Deploy %d services?", 3)
+	is.NoErr(err)
+	is.True(ok)
+	diff.TestString(t, writer.String(), "Enter value for PORT: Password for alice: \nDeploy 3 services? [no] ")
+}
+
+func TestProgress(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Alice\n\nAcme\n")
+	prompt := prompter.New(writer, reader)
+	name, err := prompt.Progress(2, 5).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	country, err := prompt.Progress(3, 5).Default("NZ").Ask(ctx, "Country?")
+	is.NoErr(err)
+	is.Equal(country, "NZ")
+	company, err := prompt.Progress(0, 5).Ask(ctx, "Company?")
+	is.NoErr(err)
+	is.Equal(company, "Acme")
+	diff.TestString(t, writer.String(), "(2/5) What is your name? (3/5) Country? [NZ] Company? ")
+}
+
+func TestWithMessages(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nmaybe\n\n")
+	prompt := prompter.New(writer, reader).WithMessages(prompter.Messages{
+		Required:       "Eingabe erforderlich",
+		ConfirmInvalid: "ungültiger Wert %q",
+	})
+	_, err := prompt.Once().Ask(ctx, "Name?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(err.Error(), "Eingabe erforderlich")
+	ok, err := prompt.ConfirmDefault(ctx, "Weiter?", false)
+	is.NoErr(err)
+	is.True(!ok)
+	diff.TestString(t, writer.String(), "Name? Weiter? [y/N] ungültiger Wert \"maybe\"\nWeiter? [y/N] ")
+}
+
+func TestWithMessagesConfirmWith(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("yes\nja\n")
+	prompt := prompter.New(writer, reader).WithMessages(prompter.Messages{
+		ConfirmWithInvalid: "ungültiger Wert %q, erlaubt sind %s",
+	})
+	ok, err := prompt.ConfirmWith(ctx, "Weiter?", []string{"ja", "j"}, []string{"nein", "n"})
+	is.NoErr(err)
+	is.True(ok)
+	diff.TestString(t, writer.String(), "Weiter? ungültiger Wert \"yes\", erlaubt sind ja, j, nein, n\nWeiter? ")
+}
+
+func TestAskInvalidUTF8(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("a\xffb\n")
+	prompt := prompter.New(io.Discard, reader)
+	input, err := prompt.Is(prompter.MaxLength(3)).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(input, "a�b")
+	is.True(utf8.ValidString(input))
+}
+
+func TestIsValue(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Example\nExample.COM\n")
+	prompt := prompter.New(writer, reader)
+	domain, err := prompt.IsValue(func(s string) (string, error) {
+		return strings.ToLower(s), nil
+	}, func(s string) (string, error) {
+		if !strings.Contains(s, ".") {
+			return s, fmt.Errorf("%q is missing a top-level domain", s)
+		}
+		return s, nil
+	}).Is(prompter.MaxLength(11)).Ask(ctx, "Domain?")
+	is.NoErr(err)
+	is.Equal(domain, "example.com")
+	diff.TestString(t, writer.String(), "Domain? \"example\" is missing a top-level domain\nDomain? ")
+}
+
+func TestIsValueSlice(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Go, CLI\n")
+	prompt := prompter.New(io.Discard, reader)
+	tags, err := prompt.IsValue(func(s string) (string, error) {
+		return strings.ToLower(s), nil
+	}).AskSlice(ctx, "Tags?", ",")
+	is.NoErr(err)
+	is.Equal(tags, []string{"go", "cli"})
+}
+
+func TestAskCancelNewline(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	writer := new(bytes.Buffer)
+	reader, pipe := io.Pipe()
+	defer pipe.Close()
+	prompt := prompter.New(writer, reader)
+	time.AfterFunc(10*time.Millisecond, cancel)
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, context.Canceled))
+	is.Equal(name, "")
+	diff.TestString(t, writer.String(), "What is your name? \n")
+}
+
+func TestCancelHint(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(writer, reader).CancelHint("(Ctrl-C to cancel)")
+	name, err := prompt.Default("Alice").Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	diff.TestString(t, writer.String(), "What is your name? [Alice] (Ctrl-C to cancel) ")
+}
+
+func TestHidden(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("tok_123\n")
+	prompt := prompter.New(writer, reader)
+	token, err := prompt.Hidden(true).Default("tok_abc").Ask(ctx, "Token?")
+	is.NoErr(err)
+	is.Equal(token, "tok_123")
+	diff.TestString(t, writer.String(), "Token? ")
+}
+
+// failingWriter fails once n writes have succeeded
+type failingWriter struct {
+	n   int
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n <= 0 {
+		return 0, w.err
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestAskWriteError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := &failingWriter{n: 2, err: syscall.EPIPE}
+	reader := bytes.NewBufferString("A\nB\nC\nAlice\n")
+	prompt := prompter.New(writer, reader)
+	// The first prompt and error succeed, then the pipe closes
+	name, err := prompt.Is(prompter.MinLength(3)).Ask(ctx, "Name?")
+	is.True(errors.Is(err, syscall.EPIPE))
+	is.Equal(err.Error(), "prompter: unable to write the prompt: broken pipe")
+	is.Equal(name, "")
+	_, err = prompt.Password(ctx, "Password?")
+	is.True(errors.Is(err, syscall.EPIPE))
+	_, err = prompt.Select(ctx, "Environment?", []string{"dev", "prod"})
+	is.True(errors.Is(err, syscall.EPIPE))
+}
+
+func TestBell(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nAl\nAlice\n")
+	prompt := prompter.New(writer, reader)
+	name, err := prompt.Bell(true).Is(prompter.MinLength(3)).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	// The bell is only rung on terminals
+	diff.TestString(t, writer.String(), "Name? Name? must be at least 3 characters, got 2\nName? ")
+}
+
+func TestClone(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Alic\nAlice\nBobby\nBob\n")
+	prompt := prompter.New(writer, reader)
+	template := prompt.Is(prompter.MinLength(3)).Default("Alice")
+	long := template.Clone().Is(prompter.MinLength(5))
+	name, err := long.Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	is.Equal(long.Attempts(), 2)
+	name, err = template.Clone().Default("Bobby").Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Bobby")
+	// The template wasn't changed by its clones
+	name, err = template.Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Bob")
+	diff.TestString(t, writer.String(), "Name? [Alice] must be at least 5 characters, got 4\nName? [Alice] Name? [Bobby] Name? [Alice] ")
+}
+
+func TestConfirmReuse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nyes\nAlice\n")
+	prompt := prompter.New(writer, reader)
+	question := prompt.ConfirmOptional(false)
+	ok, err := question.Confirm(ctx, "Continue?")
+	is.NoErr(err)
+	is.True(!ok)
+	ok, err = question.Confirm(ctx, "Continue?")
+	is.NoErr(err)
+	is.True(ok)
+	// Confirm's default and validator don't stick to the question
+	name, err := question.Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	diff.TestString(t, writer.String(), "Continue? [y/N] Continue? [y/N] Name? ")
+}
+
+func TestConfirmDefaultReuse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n\nno\n")
+	prompt := prompter.New(writer, reader)
+	question := prompt.Once()
+	ok, err := question.ConfirmDefault(ctx, "A?", true)
+	is.NoErr(err)
+	is.True(ok)
+	// The default doesn't stick to the question
+	ok, err = question.Confirm(ctx, "B?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.True(!ok)
+	ok, err = question.ConfirmKey(ctx, "C?", true)
+	is.NoErr(err)
+	is.True(!ok)
+	ok, err = question.Confirm(ctx, "D?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	is.True(!ok)
+	diff.TestString(t, writer.String(), "A? [Y/n] B? C? [Y/n] D? ")
+}
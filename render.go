@@ -0,0 +1,30 @@
+package prompter
+
+import "fmt"
+
+// Renderer passes the formatted prompts and the output around them to fn
+// instead of the writer, for apps that draw their own interface. An error
+// from fn stops the question and a nil fn writes to the writer again.
+func (p *Prompt) Renderer(fn func(prompt string) error) *Prompt {
+	p.renderPrompt = fn
+	return p
+}
+
+// ErrorRenderer renders the error lines written before asking again with fn,
+// instead of writing them to the writer. Each line is formatted the same way
+// it would've been written, with the theme's error prefix and color, without
+// the newline. An error from fn stops the question. A nil fn writes to the
+// writer again.
+func (p *Prompt) ErrorRenderer(fn func(line string) error) *Prompt {
+	p.renderError = fn
+	return p
+}
+
+// render writes s, or passes it to the renderer if there is one
+func (p *Prompt) render(s string) error {
+	if p.renderPrompt != nil {
+		return p.renderPrompt(s)
+	}
+	_, err := fmt.Fprint(p.writer, s)
+	return err
+}
@@ -0,0 +1,105 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestRenderer(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	prompt := prompter.New(writer, bytes.NewBufferString("x\n21\n"))
+	var prompts, lines []string
+	prompt.Renderer(func(prompt string) error {
+		prompts = append(prompts, prompt)
+		return nil
+	})
+	prompt.ErrorRenderer(func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	age, err := prompt.Default("30").Is(prompter.IntRange(0, 150)).Ask(ctx, "Age?")
+	is.NoErr(err)
+	is.Equal(age, "21")
+	is.Equal(prompts, []string{"Age? [30] ", "Age? [30] "})
+	is.Equal(len(lines), 1)
+	is.Equal(writer.String(), "")
+}
+
+func TestRendererError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	closed := errors.New("closed")
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("Alice\n"))
+	prompt.Renderer(func(string) error { return closed })
+	_, err := prompt.Ask(ctx, "Name?")
+	is.True(errors.Is(err, closed))
+}
+
+func TestRendererSelect(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	prompt := prompter.New(writer, bytes.NewBufferString("4\n2\nsecret\n"))
+	var output []string
+	prompt.Renderer(func(s string) error {
+		output = append(output, s)
+		return nil
+	})
+	prompt.ErrorRenderer(func(line string) error {
+		output = append(output, "error: "+line)
+		return nil
+	})
+	env, err := prompt.Select(ctx, "Environment?", []string{"dev", "prod"})
+	is.NoErr(err)
+	is.Equal(env, "prod")
+	pass, err := prompt.Password(ctx, "Password?")
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+	is.Equal(output, []string{
+		"1) dev\n",
+		"2) prod\n",
+		"Environment? ",
+		"error: invalid option \"4\", must choose 1-2",
+		"Environment? ",
+		"Password? ",
+		"\n",
+	})
+	is.Equal(writer.String(), "")
+}
+
+func TestErrorRendererError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	closed := errors.New("closed")
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("x\n21\n"))
+	prompt.ErrorRenderer(func(string) error { return closed })
+	_, err := prompt.Is(prompter.IntRange(0, 150)).Ask(ctx, "Age?")
+	is.True(errors.Is(err, closed))
+}
+
+func TestRendererOutputError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	closed := errors.New("closed")
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("secret\nAlice\n"))
+	// Only the prompts can be rendered
+	prompt.Renderer(func(s string) error {
+		if strings.HasSuffix(s, "? ") {
+			return nil
+		}
+		return closed
+	})
+	_, err := prompt.Password(ctx, "Password?")
+	is.True(errors.Is(err, closed))
+	prompt.EchoAnswers(true)
+	_, err = prompt.Ask(ctx, "Name?")
+	is.True(errors.Is(err, closed))
+}
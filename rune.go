@@ -0,0 +1,76 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// AskRune asks for a single key from the allowed set
+func (p *Prompt) AskRune(ctx context.Context, prompt string, allowed []rune) (rune, error) {
+	q := newQuestion(p)
+	return q.AskRune(ctx, prompt, allowed)
+}
+
+// AskRune asks for a single key from the allowed set. On a terminal, the key
+// is read without waiting for enter and echoed back. Otherwise, the first
+// character of the next line is used. Keys that aren't allowed are rejected
+// and the question is asked again. Enter uses the default, which is set with
+// Default, like Default("q").
+func (q *Question) AskRune(ctx context.Context, prompt string, allowed []rune) (rune, error) {
+	keys := make([]string, len(allowed))
+	for i, r := range allowed {
+		keys[i] = string(r)
+	}
+	defer q.withValidator(check(func(s string) error {
+		if s == "" {
+			return nil
+		}
+		if r, _ := utf8.DecodeRuneInString(s); !slices.Contains(allowed, r) {
+			return fmt.Errorf("invalid key %q, must be one of %s", string(r), strings.Join(keys, ", "))
+		}
+		return nil
+	}))()
+
+	answer, err := q.ask(ctx, prompt, false, func(ctx context.Context) (string, error) {
+		return q.readAsync(ctx, q.scanRune)
+	})
+	if err != nil {
+		return 0, err
+	} else if answer == "" {
+		return 0, nil
+	}
+	r, _ := utf8.DecodeRuneInString(answer)
+	if !slices.Contains(allowed, r) {
+		// Only the default can get here, since it isn't validated
+		return 0, fmt.Errorf("prompter: default %q must be one of %s", string(r), strings.Join(keys, ", "))
+	}
+	return r, nil
+}
+
+// scanRune reads a line when the input isn't a terminal, otherwise it reads a
+// single key press and echoes it. Enter is returned as an empty answer.
+func (q *Question) scanRune() (string, error) {
+	p := q.prompter
+	if !p.isTerminal() {
+		return q.scanLine()
+	}
+	key, err := q.scanKey()
+	if err != nil {
+		if errors.Is(err, ErrInterrupted) {
+			if err := p.render("\r\n"); err != nil {
+				return "", err
+			}
+		}
+		return "", err
+	} else if key == "\n" {
+		key = ""
+	}
+	if err := p.render(key + "\r\n"); err != nil {
+		return "", err
+	}
+	return key, nil
+}
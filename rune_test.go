@@ -0,0 +1,45 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskRune(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("x\nskip\n")
+	prompt := prompter.New(writer, reader)
+	key, err := prompt.AskRune(ctx, "Action?", []rune{'s', 'q'})
+	is.NoErr(err)
+	is.Equal(key, 's')
+	diff.TestString(t, writer.String(), "Action? invalid key \"x\", must be one of s, q\nAction? ")
+}
+
+func TestAskRuneDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("\n"))
+	key, err := prompt.Default("q").AskRune(ctx, "Action?", []rune{'s', 'q'})
+	is.NoErr(err)
+	is.Equal(key, 'q')
+	// The default has to be one of the allowed keys
+	prompt = prompter.New(new(bytes.Buffer), bytes.NewBufferString("\n"))
+	_, err = prompt.Default("x").AskRune(ctx, "Action?", []rune{'s', 'q'})
+	is.True(err != nil)
+}
+
+func TestAskRuneMaxAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.New(new(bytes.Buffer), bytes.NewBufferString("x\ny\n"))
+	_, err := prompt.MaxAttempts(2).AskRune(ctx, "Action?", []rune{'s', 'q'})
+	is.True(errors.Is(err, prompter.ErrValidation))
+}
@@ -0,0 +1,133 @@
+package prompter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrNoMoreAnswers is returned when a scripted prompt is asked more questions
+// than it has answers for
+var ErrNoMoreAnswers = errors.New("prompter: no more scripted answers")
+
+// Scripted creates a prompt that answers each question with the next answer,
+// regardless of what's asked. It's useful for testing code that asks
+// questions. Passwords and confirmations use the same answers. An empty answer
+// is like pressing enter, so the default is used. Prompts and error messages
+// are discarded. Once the answers run out, ErrNoMoreAnswers is returned.
+func Scripted(answers ...string) *Prompt {
+	return New(io.Discard, &scriptReader{answers: answers})
+}
+
+// Capture is like Scripted, but the prompts and error messages are written
+// to the returned buffer, so tests can check what was asked
+func Capture(answers ...string) (*Prompt, *bytes.Buffer) {
+	writer := new(bytes.Buffer)
+	return New(writer, &scriptReader{answers: answers}), writer
+}
+
+// scriptReader reads the answers as lines
+type scriptReader struct {
+	answers []string
+	line    *strings.Reader
+}
+
+func (r *scriptReader) Read(p []byte) (int, error) {
+	for r.line == nil || r.line.Len() == 0 {
+		if len(r.answers) == 0 {
+			return 0, ErrNoMoreAnswers
+		}
+		r.line = strings.NewReader(r.answers[0] + "\n")
+		r.answers = r.answers[1:]
+	}
+	return r.line.Read(p)
+}
+
+// ScriptedDelayed is like Scripted, but each answer is typed out a character
+// at a time, waiting for the delay before each character and before pressing
+// enter. The prompts and answers are written to stdout, or the PromptWriter.
+// It's useful for recording demos that look like someone is typing.
+// Passwords are typed out too, so use fake ones. Cancelling the question
+// stops the typing and skips the rest of the answer, since the characters
+// typed so far are dropped with the question.
+func ScriptedDelayed(delay time.Duration, answers ...string) *Prompt {
+	r := &typingReader{delay: delay, answers: answers, interrupt: make(chan struct{})}
+	r.prompt = New(os.Stdout, r)
+	return r.prompt
+}
+
+// typingReader reads the answers a character at a time, echoing each one
+// after a delay. It supports read deadlines so typing can be cancelled.
+type typingReader struct {
+	// prompt is where the answers are typed out
+	prompt  *Prompt
+	delay   time.Duration
+	answers []string
+	line    string
+
+	// interrupt is closed when the read deadline has passed
+	mu        sync.Mutex
+	interrupt chan struct{}
+	timer     *time.Timer
+}
+
+var _ DeadlineReader = (*typingReader)(nil)
+
+func (r *typingReader) Read(p []byte) (int, error) {
+	if r.line == "" {
+		if len(r.answers) == 0 {
+			return 0, ErrNoMoreAnswers
+		}
+		r.line = r.answers[0] + "\n"
+		r.answers = r.answers[1:]
+	}
+	r.mu.Lock()
+	interrupt := r.interrupt
+	r.mu.Unlock()
+	select {
+	case <-time.After(r.delay):
+	case <-interrupt:
+		r.line = ""
+		return 0, os.ErrDeadlineExceeded
+	}
+	// Type the next character
+	_, size := utf8.DecodeRuneInString(r.line)
+	if len(p) < size {
+		size = len(p)
+	}
+	n := copy(p, r.line[:size])
+	r.line = r.line[n:]
+	r.prompt.writer.Write(p[:n])
+	return n, nil
+}
+
+// SetReadDeadline interrupts the typing once the deadline has passed. A zero
+// deadline clears it.
+func (r *typingReader) SetReadDeadline(t time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	// Start over with a fresh channel if the last deadline has passed
+	select {
+	case <-r.interrupt:
+		r.interrupt = make(chan struct{})
+	default:
+	}
+	if t.IsZero() {
+		return nil
+	} else if !t.After(time.Now()) {
+		close(r.interrupt)
+		return nil
+	}
+	interrupt := r.interrupt
+	r.timer = time.AfterFunc(time.Until(t), func() { close(interrupt) })
+	return nil
+}
@@ -0,0 +1,92 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestScripted(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.Scripted("Alice", "secret", "yes", "", "27")
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	pass, err := prompt.Password(ctx, "Password:")
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+	ok, err := prompt.Confirm(ctx, "Create user?")
+	is.NoErr(err)
+	is.True(ok)
+	country, err := prompt.Default("NZ").Ask(ctx, "Country?")
+	is.NoErr(err)
+	is.Equal(country, "NZ")
+	age, err := prompt.AskInt(ctx, "Age?")
+	is.NoErr(err)
+	is.Equal(age, 27)
+	_, err = prompt.Optional(true).Ask(ctx, "Company?")
+	is.True(errors.Is(err, prompter.ErrNoMoreAnswers))
+	is.Equal(err.Error(), "prompter: no more scripted answers")
+}
+
+func TestScriptedRetry(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt := prompter.Scripted("Al", "Alice")
+	name, err := prompt.Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+}
+
+func TestCapture(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	prompt, output := prompter.Capture("Al", "Alice", "secret")
+	name, err := prompt.Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	pass, err := prompt.Password(ctx, "Password:")
+	is.NoErr(err)
+	is.Equal(pass, "secret")
+	diff.TestString(t, output.String(), "What is your name? must be at least 3 characters, got 2\nWhat is your name? Password: \n")
+}
+
+func TestScriptedDelayed(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	output := new(bytes.Buffer)
+	prompt := prompter.ScriptedDelayed(time.Millisecond, "Alice", "", "日本").PromptWriter(output)
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	country, err := prompt.Default("NZ").Ask(ctx, "Country?")
+	is.NoErr(err)
+	is.Equal(country, "NZ")
+	city, err := prompt.Ask(ctx, "City?")
+	is.NoErr(err)
+	is.Equal(city, "日本")
+	_, err = prompt.Ask(ctx, "Company?")
+	is.True(errors.Is(err, prompter.ErrNoMoreAnswers))
+	diff.TestString(t, output.String(), "What is your name? Alice\nCountry? [NZ] \nCity? 日本\nCompany? ")
+}
+
+func TestScriptedDelayedCancel(t *testing.T) {
+	is := is.New(t)
+	output := new(bytes.Buffer)
+	prompt := prompter.ScriptedDelayed(20*time.Millisecond, "Alice", "Bob").PromptWriter(output)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := prompt.Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	// The rest of the answer is skipped
+	name, err := prompt.Ask(context.Background(), "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Bob")
+}
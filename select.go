@@ -0,0 +1,158 @@
+package prompter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Select asks the user to choose one of the options and returns the option
+func (p *Prompt) Select(ctx context.Context, prompt string, options []string) (string, error) {
+	q := newQuestion(p)
+	return q.Select(ctx, prompt, options)
+}
+
+// SelectIndex asks the user to choose one of the options and returns the
+// 0-based index of the chosen option
+func (p *Prompt) SelectIndex(ctx context.Context, prompt string, options []string) (int, error) {
+	q := newQuestion(p)
+	return q.SelectIndex(ctx, prompt, options)
+}
+
+// Select asks the user to choose one of the options and returns the option.
+// An empty string is returned when the question is optional and nothing was
+// chosen.
+func (q *Question) Select(ctx context.Context, prompt string, options []string) (string, error) {
+	index, err := q.SelectIndex(ctx, prompt, options)
+	if err != nil {
+		return "", err
+	} else if index < 0 {
+		return "", nil
+	}
+	return options[index], nil
+}
+
+// SelectIndex asks the user to choose one of the options and returns the
+// 0-based index of the chosen option. The user may enter either the number
+// shown next to the option or the option itself. -1 is returned when the
+// question is optional and nothing was chosen.
+func (q *Question) SelectIndex(ctx context.Context, prompt string, options []string) (int, error) {
+	// Print out the numbered list of options
+	if err := q.writeOptions(options); err != nil {
+		return -1, err
+	}
+
+	// Add a validator to ensure the input is one of the options
+	defer q.withValidator(check(func(s string) error {
+		if s == "" {
+			return nil
+		}
+		if optionIndex(options, s) < 0 {
+			return fmt.Errorf("invalid option %q, must choose 1-%d", s, len(options))
+		}
+		return nil
+	}))()
+
+	input, err := q.Ask(ctx, prompt)
+	if err != nil {
+		return -1, err
+	} else if input == "" {
+		return -1, nil
+	}
+
+	index := optionIndex(options, input)
+	if index < 0 {
+		return -1, fmt.Errorf("prompter: %q is not a valid option", input)
+	}
+	return index, nil
+}
+
+// optionIndex finds the option by its value or by its 1-based number. Values
+// take precedence over numbers. Returns -1 if there's no match.
+func optionIndex(options []string, input string) int {
+	for i, option := range options {
+		if option == input {
+			return i
+		}
+	}
+	n, err := strconv.Atoi(input)
+	if err != nil || n < 1 || n > len(options) {
+		return -1
+	}
+	return n - 1
+}
+
+// MultiSelect asks the user to choose any number of the options and returns
+// the chosen options
+func (p *Prompt) MultiSelect(ctx context.Context, prompt string, options []string) ([]string, error) {
+	q := newQuestion(p)
+	return q.MultiSelect(ctx, prompt, options)
+}
+
+// MultiSelect asks the user to choose any number of the options and returns
+// the chosen options. The user enters a comma-separated list of option
+// numbers and/or option values. Duplicate selections are removed and the
+// chosen options are returned in the order they were declared. The default
+// may also be a comma-separated list.
+func (q *Question) MultiSelect(ctx context.Context, prompt string, options []string) ([]string, error) {
+	// Print out the numbered list of options
+	if err := q.writeOptions(options); err != nil {
+		return nil, err
+	}
+
+	// Add a validator to ensure every input is one of the options
+	defer q.withValidator(check(func(s string) error {
+		selected, err := selectOptions(options, s)
+		if err != nil {
+			return err
+		} else if len(selected) == 0 && !q.optional {
+			return fmt.Errorf("must choose at least one option")
+		}
+		return nil
+	}))()
+
+	input, err := q.Ask(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := selectOptions(options, input)
+	if err != nil {
+		return nil, fmt.Errorf("prompter: %w", err)
+	}
+	return selected, nil
+}
+
+// writeOptions writes out the numbered list of options
+func (q *Question) writeOptions(options []string) error {
+	for i, option := range options {
+		if err := q.prompter.render(fmt.Sprintf("%d) %s\n", i+1, option)); err != nil {
+			return fmt.Errorf("prompter: unable to write the options: %w", err)
+		}
+	}
+	return nil
+}
+
+// selectOptions parses a comma-separated list of options
+func selectOptions(options []string, input string) ([]string, error) {
+	chosen := make([]bool, len(options))
+	for _, token := range strings.Split(input, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		index := optionIndex(options, token)
+		if index < 0 {
+			return nil, fmt.Errorf("invalid option %q, must choose 1-%d", token, len(options))
+		}
+		chosen[index] = true
+	}
+	selected := []string{}
+	for i, option := range options {
+		if chosen[i] {
+			selected = append(selected, option)
+		}
+	}
+	return selected, nil
+}
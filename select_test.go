@@ -0,0 +1,100 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestSelect(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("4\nstaging\n")
+	prompt := prompter.New(writer, reader)
+	env, err := prompt.Select(ctx, "Which environment?", []string{"dev", "staging", "prod"})
+	is.NoErr(err)
+	is.Equal(env, "staging")
+	diff.TestString(t, writer.String(), "1) dev\n2) staging\n3) prod\nWhich environment? invalid option \"4\", must choose 1-3\nWhich environment? ")
+}
+
+func TestSelectIndex(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("3\nstaging\n")
+	prompt := prompter.New(os.Stdout, reader)
+	index, err := prompt.SelectIndex(ctx, "Which environment?", []string{"dev", "staging", "prod"})
+	is.NoErr(err)
+	is.Equal(index, 2)
+	index, err = prompt.SelectIndex(ctx, "Which environment?", []string{"dev", "staging", "prod"})
+	is.NoErr(err)
+	is.Equal(index, 1)
+}
+
+func TestSelectIndexDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	index, err := prompt.Default("prod").SelectIndex(ctx, "Which environment?", []string{"dev", "staging", "prod"})
+	is.NoErr(err)
+	is.Equal(index, 2)
+}
+
+func TestSelectIndexOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	index, err := prompt.Optional(true).SelectIndex(ctx, "Which environment?", []string{"dev", "staging", "prod"})
+	is.NoErr(err)
+	is.Equal(index, -1)
+}
+
+func TestSelectIndexErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	index, err := prompt.SelectIndex(ctx, "Which environment?", []string{"dev", "staging", "prod"})
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(index, -1)
+}
+
+func TestMultiSelect(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("1,qa\n3, 1,staging,3\n")
+	prompt := prompter.New(writer, reader)
+	envs, err := prompt.MultiSelect(ctx, "Which environments?", []string{"dev", "staging", "prod"})
+	is.NoErr(err)
+	is.Equal(envs, []string{"dev", "staging", "prod"})
+	diff.TestString(t, writer.String(), "1) dev\n2) staging\n3) prod\nWhich environments? invalid option \"qa\", must choose 1-3\nWhich environments? ")
+}
+
+func TestMultiSelectDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	envs, err := prompt.Default("prod,1").MultiSelect(ctx, "Which environments?", []string{"dev", "staging", "prod"})
+	is.NoErr(err)
+	is.Equal(envs, []string{"dev", "prod"})
+}
+
+func TestMultiSelectOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	envs, err := prompt.Optional(true).MultiSelect(ctx, "Which environments?", []string{"dev", "staging", "prod"})
+	is.NoErr(err)
+	is.Equal(envs, []string{})
+}
@@ -0,0 +1,58 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// AskSlice asks for a list of values separated by sep and returns them
+func (p *Prompt) AskSlice(ctx context.Context, prompt, sep string) ([]string, error) {
+	q := newQuestion(p)
+	return q.AskSlice(ctx, prompt, sep)
+}
+
+// AskSlice asks for a list of values separated by sep and returns them. Each
+// value is trimmed and empty values are dropped. Validators run against each
+// value and the whole list is asked for again if any value is invalid. The
+// default may also be a list separated by sep.
+func (q *Question) AskSlice(ctx context.Context, prompt, sep string) ([]string, error) {
+	// Validate each value in the list, rather than the whole input
+	validators := q.validators
+	defer func() { q.validators = validators }()
+	q.validators = []func(string) (string, error){func(s string) (string, error) {
+		values := splitList(s, sep)
+		if len(values) == 0 && !q.optional {
+			return s, errors.New("must enter at least one value")
+		}
+		for i, value := range values {
+			value, err := runValidators(validators, value)
+			if err != nil {
+				return s, err
+			}
+			values[i] = value
+		}
+		return strings.Join(values, sep), nil
+	}}
+
+	input, err := q.Ask(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitList(input, sep), nil
+}
+
+// splitList splits the input by sep, trimming each value and dropping empty
+// values
+func splitList(input, sep string) []string {
+	values := []string{}
+	for _, value := range strings.Split(input, sep) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
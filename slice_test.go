@@ -0,0 +1,54 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskSlice(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString(" go, cli ,, prompts \n")
+	prompt := prompter.New(os.Stdout, reader)
+	tags, err := prompt.AskSlice(ctx, "Tags?", ",")
+	is.NoErr(err)
+	is.Equal(tags, []string{"go", "cli", "prompts"})
+}
+
+func TestAskSliceValidate(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("go,CLI\n,\ngo,cli\n")
+	prompt := prompter.New(writer, reader)
+	tags, err := prompt.Is(prompter.MatchString(`^[a-z]+$`, "tags must be lowercase")).AskSlice(ctx, "Tags?", ",")
+	is.NoErr(err)
+	is.Equal(tags, []string{"go", "cli"})
+	diff.TestString(t, writer.String(), "Tags? tags must be lowercase\nTags? must enter at least one value\nTags? ")
+}
+
+func TestAskSliceDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	tags, err := prompt.Default("go cli").AskSlice(ctx, "Tags?", " ")
+	is.NoErr(err)
+	is.Equal(tags, []string{"go", "cli"})
+}
+
+func TestAskSliceOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	tags, err := prompt.Optional(true).AskSlice(ctx, "Tags?", ",")
+	is.NoErr(err)
+	is.Equal(tags, []string{})
+}
@@ -0,0 +1,58 @@
+package prompter
+
+// Source provides answers by key when the input has ended, so the same
+// questions can be asked interactively and answered in CI
+type Source interface {
+	Lookup(key string) (answer string, ok bool)
+}
+
+// SourceFunc adapts a function into a Source. For example,
+// SourceFunc(os.LookupEnv) answers from environment variables.
+type SourceFunc func(key string) (string, bool)
+
+// Lookup calls the function
+func (fn SourceFunc) Lookup(key string) (string, bool) {
+	return fn(key)
+}
+
+// MapSource answers from a map
+type MapSource map[string]string
+
+// Lookup finds the answer in the map
+func (m MapSource) Lookup(key string) (string, bool) {
+	answer, ok := m[key]
+	return answer, ok
+}
+
+// WithFallback sets the source of answers for when the input ends before a
+// question is answered, instead of returning ErrClosed. Questions are looked
+// up by their key, so only questions with a key use the fallback. Answers
+// from the fallback take precedence over defaults and are still validated.
+func (p *Prompt) WithFallback(source Source) *Prompt {
+	p.fallback = source
+	return p
+}
+
+// Key names the question, which is used to look up its answer in the
+// fallback source
+func (p *Prompt) Key(key string) *Question {
+	q := newQuestion(p)
+	q.key = key
+	return q
+}
+
+// Key names the question, which is used to look up its answer in the
+// fallback source. Questions in a form are named by their form key.
+func (q *Question) Key(key string) *Question {
+	q.key = key
+	return q
+}
+
+// fallbackAnswer looks up the answer in the fallback source
+func (q *Question) fallbackAnswer() (string, bool) {
+	p := q.prompter
+	if p.fallback == nil || q.key == "" {
+		return "", false
+	}
+	return p.fallback.Lookup(q.key)
+}
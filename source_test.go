@@ -0,0 +1,60 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestWithFallback(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Alice\n")
+	prompt := prompter.New(io.Discard, reader).WithFallback(prompter.MapSource{
+		"name": "Bob",
+		"env":  "prod",
+	})
+	// Answers are read from the input while there is some
+	name, err := prompt.Key("name").Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	// Then they come from the fallback, even over the default
+	env, err := prompt.Key("env").Default("dev").Ask(ctx, "Environment?")
+	is.NoErr(err)
+	is.Equal(env, "prod")
+	// Questions without a key or a fallback answer are closed
+	_, err = prompt.Ask(ctx, "Name?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	_, err = prompt.Key("region").Ask(ctx, "Region?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+}
+
+func TestWithFallbackValidators(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(io.Discard, reader).WithFallback(prompter.SourceFunc(func(key string) (string, bool) {
+		return "abc", key == "port"
+	}))
+	_, err := prompt.Key("port").AskInt(ctx, "Port?")
+	is.True(errors.Is(err, prompter.ErrClosed))
+	is.True(errors.Is(err, prompter.ErrValidation))
+}
+
+func TestWithFallbackForm(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Alice\n")
+	prompt := prompter.New(io.Discard, reader).WithFallback(prompter.MapSource{"password": "secret"})
+	form := prompt.Form()
+	form.Ask("name", "Name?")
+	form.Password("password", "Password?")
+	answers, err := form.Run(ctx)
+	is.NoErr(err)
+	is.Equal(answers, map[string]string{"name": "Alice", "password": "secret"})
+}
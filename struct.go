@@ -0,0 +1,165 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AskStruct asks for each exported string, number, bool or duration field of
+// the struct v points to, configured with the prompt ("-" skips the field),
+// default, optional and password tags
+func (p *Prompt) AskStruct(ctx context.Context, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("prompter: AskStruct needs a pointer to a struct, got %T", v)
+	}
+	fields, err := structFields(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		q := newQuestion(p)
+		if field.defaultTo != "" {
+			q.Default(field.defaultTo)
+		}
+		q.Optional(field.optional)
+		if err := field.ask(ctx, q, rv.Elem().FieldByIndex(field.index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structField is a struct field to ask for
+type structField struct {
+	name      string
+	index     []int
+	prompt    string
+	defaultTo string
+	optional  bool
+	password  bool
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// structFields reads the fields to ask for from the struct tags
+func structFields(t reflect.Type) (fields []*structField, err error) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("prompt") == "-" {
+			continue
+		}
+		field := &structField{
+			name:      f.Name,
+			index:     f.Index,
+			prompt:    f.Tag.Get("prompt"),
+			defaultTo: f.Tag.Get("default"),
+		}
+		if field.prompt == "" {
+			field.prompt = f.Name
+		}
+		if field.optional, err = boolTag(f, "optional"); err != nil {
+			return nil, err
+		}
+		if field.password, err = boolTag(f, "password"); err != nil {
+			return nil, err
+		}
+		switch {
+		case f.Type == durationType:
+		case f.Type.Kind() == reflect.String:
+		case field.password:
+			return nil, fmt.Errorf("prompter: password field %s must be a string", f.Name)
+		case isIntKind(f.Type.Kind()), isFloatKind(f.Type.Kind()), f.Type.Kind() == reflect.Bool:
+		default:
+			return nil, fmt.Errorf("prompter: unsupported type %s for field %s", f.Type, f.Name)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// boolTag parses a boolean struct tag, which is false when it's missing
+func boolTag(f reflect.StructField, key string) (bool, error) {
+	tag, ok := f.Tag.Lookup(key)
+	if !ok {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(tag)
+	if err != nil {
+		return false, fmt.Errorf("prompter: invalid %s tag %q for field %s", key, tag, f.Name)
+	}
+	return b, nil
+}
+
+// ask for the field and set the value
+func (f *structField) ask(ctx context.Context, q *Question, value reflect.Value) error {
+	t := value.Type()
+	switch {
+	case t == durationType:
+		d, err := q.AskDuration(ctx, f.prompt)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+	case t.Kind() == reflect.String && f.password:
+		s, err := q.Password(ctx, f.prompt)
+		if err != nil {
+			return err
+		}
+		value.SetString(s)
+	case t.Kind() == reflect.String:
+		s, err := q.Ask(ctx, f.prompt)
+		if err != nil {
+			return err
+		}
+		value.SetString(s)
+	case t.Kind() == reflect.Int:
+		n, err := q.AskInt(ctx, f.prompt)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(n))
+	case isIntKind(t.Kind()):
+		n, err := askParse(ctx, q, f.prompt, func(s string) (int64, error) {
+			n, err := strconv.ParseInt(strings.TrimSpace(s), 10, t.Bits())
+			if err != nil {
+				return 0, errors.New("please enter a whole number")
+			}
+			return n, nil
+		})
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case isFloatKind(t.Kind()):
+		n, err := q.AskFloat(ctx, f.prompt)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(n)
+	case t.Kind() == reflect.Bool:
+		b, err := q.AskBool(ctx, f.prompt)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	}
+	return nil
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
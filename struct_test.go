@@ -0,0 +1,79 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+type signup struct {
+	Name     string        `prompt:"What is your name?"`
+	Age      int           `prompt:"How old are you?"`
+	Height   float64       `prompt:"How tall are you?" optional:"true"`
+	Password string        `prompt:"Password:" password:"true"`
+	Admin    bool          `prompt:"Admin?" default:"false"`
+	Timeout  time.Duration `prompt:"Timeout?" default:"30s"`
+	Level    int8          `prompt:"Level?"`
+	Country  string
+	internal string
+	Skipped  string `prompt:"-"`
+}
+
+func TestAskStruct(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Alice\nold\n30\n\nsecret\n\n1m\n200\n5\nNZ\n")
+	prompt := prompter.New(writer, reader)
+	var s signup
+	is.NoErr(prompt.AskStruct(ctx, &s))
+	is.Equal(s, signup{
+		Name:     "Alice",
+		Age:      30,
+		Password: "secret",
+		Timeout:  time.Minute,
+		Level:    5,
+		Country:  "NZ",
+	})
+	diff.TestString(t, writer.String(), "What is your name? How old are you? please enter a whole number\nHow old are you? How tall are you? Password: \nAdmin? [false] Timeout? [30s] Level? please enter a whole number\nLevel? Country ")
+}
+
+func TestAskStructErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Alice\n")
+	prompt := prompter.New(os.Stdout, reader)
+	var s signup
+	err := prompt.AskStruct(ctx, &s)
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(s.Name, "Alice")
+}
+
+func TestAskStructInvalid(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("Alice\n")
+	prompt := prompter.New(os.Stdout, reader)
+	var s signup
+	is.Equal(prompt.AskStruct(ctx, s).Error(), "prompter: AskStruct needs a pointer to a struct, got prompter_test.signup")
+	var unsupported struct {
+		Name string
+		Tags []string
+	}
+	is.Equal(prompt.AskStruct(ctx, &unsupported).Error(), "prompter: unsupported type []string for field Tags")
+	var password struct {
+		Pin int `password:"true"`
+	}
+	is.Equal(prompt.AskStruct(ctx, &password).Error(), "prompter: password field Pin must be a string")
+	var optional struct {
+		Name string `optional:"maybe"`
+	}
+	is.Equal(prompt.AskStruct(ctx, &optional).Error(), `prompter: invalid optional tag "maybe" for field Name`)
+}
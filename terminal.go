@@ -0,0 +1,151 @@
+package prompter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// isTerminal is true when reading from a terminal
+func (p *Prompt) isTerminal() bool {
+	return p.fd > -1 && term.IsTerminal(p.fd)
+}
+
+// writesTerminal is true when writing to a terminal
+func (p *Prompt) writesTerminal() bool {
+	return p.writerFd > -1 && term.IsTerminal(p.writerFd)
+}
+
+// makeRaw puts the terminal into raw mode and returns a function to restore
+// it. The state is kept on the prompt so the terminal can also be restored
+// when a read is abandoned.
+func (p *Prompt) makeRaw() (restore func(), err error) {
+	state, err := term.MakeRaw(p.fd)
+	if err != nil {
+		return nil, err
+	}
+	p.rawMu.Lock()
+	p.rawState = state
+	p.rawMu.Unlock()
+	return p.restoreTerminal, nil
+}
+
+// restoreTerminal restores the terminal if it's in raw mode. It's safe to call
+// more than once.
+func (p *Prompt) restoreTerminal() {
+	p.rawMu.Lock()
+	defer p.rawMu.Unlock()
+	if p.rawState == nil {
+		return
+	}
+	term.Restore(p.fd, p.rawState)
+	p.rawState = nil
+}
+
+// LineEditing toggles the line editor when reading from a terminal. The line
+// editor supports moving with the arrow keys, Home and End, deleting with
+// Backspace and Delete, and the Emacs-style Ctrl-A, Ctrl-E, Ctrl-B, Ctrl-F,
+// Ctrl-K, Ctrl-U and Ctrl-W shortcuts.
+func (p *Prompt) LineEditing(enable bool) *Prompt {
+	p.lineEditing = enable
+	return p
+}
+
+// editing is true when the input should be read with the line editor
+func (q *Question) editing() bool {
+	p := q.prompter
+	return (p.lineEditing || q.editable || q.complete != nil || p.history != nil) && p.isTerminal()
+}
+
+// errCleared is returned when the default that was typed in is cleared before
+// pressing enter, so the default isn't used for the empty answer
+var errCleared = errors.New("prompter: default cleared")
+
+// readLine puts the terminal into raw mode and reads a line with the line
+// editor. If the question is editable, the default is typed in for the user.
+func (q *Question) readLine() (string, error) {
+	p := q.prompter
+	restore, err := p.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+	e := &editor{r: q.keyReader(), w: p.writer, complete: q.completer()}
+	if p.history != nil {
+		e.history = p.history.Entries()
+		e.historyIndex = len(e.history)
+	}
+	prefilled := false
+	if q.editable {
+		e.insert([]rune(q.defaultTo)...)
+		prefilled = q.defaultTo != ""
+	}
+	input, err := e.readLine()
+	if err == nil && prefilled && input == "" {
+		return "", errCleared
+	} else if err != nil {
+		if !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return q.onEOF(input)
+	}
+	if p.history != nil {
+		p.history.Add(input)
+	}
+	return input, nil
+}
+
+// readMasked puts the terminal into raw mode and reads a password, echoing the
+// mask for each character typed
+func (q *Question) readMasked() (string, error) {
+	p := q.prompter
+	restore, err := p.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+	return readRunes(q.keyReader(), p.writer, q.mask, q.reveal, 0)
+}
+
+// readRunes reads from a raw terminal until enter is pressed or, if n is more
+// than zero, n characters have been typed. Each character is echoed as its
+// mask, or as typed when there's no mask, and shown for the reveal duration
+// first if there is one. Ctrl-C returns ErrInterrupted.
+func readRunes(r io.RuneReader, w io.Writer, mask rune, reveal time.Duration, n int) (string, error) {
+	var line []rune
+	m := &masker{w: w, mask: mask, reveal: reveal}
+	for n <= 0 || len(line) < n {
+		ch, _, err := r.ReadRune()
+		// Any key masks the character that's being revealed
+		m.hide()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(line) > 0 {
+				return string(line), nil
+			}
+			return "", err
+		}
+		switch {
+		case ch == '\r' || ch == '\n':
+			return string(line), nil
+		case ch == keyCtrlC:
+			fmt.Fprint(w, "\r\n")
+			return "", ErrInterrupted
+		case ch == keyBackspace || ch == keyDelete:
+			if len(line) == 0 {
+				continue
+			}
+			m.erase(line[len(line)-1])
+			line = line[:len(line)-1]
+		case ch < ' ':
+			// Ignore other control characters
+			continue
+		default:
+			line = append(line, ch)
+			m.echo(ch)
+		}
+	}
+	return string(line), nil
+}
@@ -0,0 +1,345 @@
+package prompter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"golang.org/x/sys/unix"
+)
+
+// openPty opens a pseudo-terminal, returning the controlling side and the
+// terminal side
+func openPty(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("unable to open a pty: %v", err)
+	}
+	t.Cleanup(func() { ptmx.Close() })
+	if err := unix.IoctlSetPointerInt(int(ptmx.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		t.Skipf("unable to unlock the pty: %v", err)
+	}
+	n, err := unix.IoctlGetInt(int(ptmx.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		t.Skipf("unable to get the pty number: %v", err)
+	}
+	tty, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("unable to open the pty: %v", err)
+	}
+	t.Cleanup(func() { tty.Close() })
+	return ptmx, tty
+}
+
+// isCooked is true when the terminal is echoing and reading whole lines
+func isCooked(t *testing.T, tty *os.File) bool {
+	t.Helper()
+	// Calling tty.Fd() would put the terminal into blocking mode, which stops
+	// read deadlines from working
+	termios, err := unix.IoctlGetTermios(getFd(tty), unix.TCGETS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return termios.Lflag&unix.ICANON != 0 && termios.Lflag&unix.ECHO != 0
+}
+
+// pressKey waits for the terminal to be in raw mode before pressing the key,
+// so the key isn't handled by the terminal
+func pressKey(t *testing.T, ptmx, tty *os.File, key string) {
+	for isCooked(t, tty) {
+		time.Sleep(time.Millisecond)
+	}
+	ptmx.Write([]byte(key))
+}
+
+// blockingReader hides the read deadline, so reads can't be interrupted
+type blockingReader struct {
+	file *os.File
+}
+
+func (r blockingReader) Read(p []byte) (int, error) {
+	return r.file.Read(p)
+}
+
+func (r blockingReader) Fd() uintptr {
+	return r.file.Fd()
+}
+
+func TestRestoreTerminalInterrupted(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	is.True(isCooked(t, tty))
+	prompt := New(tty, tty).LineEditing(true)
+	go pressKey(t, ptmx, tty, "Alice\x03")
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, ErrInterrupted))
+	is.Equal(name, "")
+	is.True(isCooked(t, tty))
+}
+
+func TestRestoreTerminalCancel(t *testing.T) {
+	is := is.New(t)
+	ptmx, tty := openPty(t)
+	prompt := New(tty, tty).LineEditing(true)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.Equal(name, "")
+	is.True(isCooked(t, tty))
+	// The next read picks up the input
+	ptmx.Write([]byte("Alice\r"))
+	name, err = prompt.Ask(context.Background(), "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+}
+
+func TestRestoreTerminalAbandoned(t *testing.T) {
+	is := is.New(t)
+	ptmx, tty := openPty(t)
+	prompt := New(tty, blockingReader{tty}).LineEditing(true)
+	is.True(prompt.deadliner == nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	name, err := prompt.Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.Equal(name, "")
+	is.True(isCooked(t, tty))
+	// Unblock the abandoned read
+	ptmx.Write([]byte("Alice\n"))
+	name, err = prompt.Ask(context.Background(), "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+}
+
+func TestReadKeyTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	prompt := New(tty, tty)
+	go pressKey(t, ptmx, tty, "y")
+	key, err := prompt.ReadKey(ctx)
+	is.NoErr(err)
+	is.Equal(key, 'y')
+	is.True(isCooked(t, tty))
+	go pressKey(t, ptmx, tty, "\r")
+	key, err = prompt.ReadKey(ctx)
+	is.NoErr(err)
+	is.Equal(key, '\n')
+	go pressKey(t, ptmx, tty, "\x03")
+	_, err = prompt.ReadKey(ctx)
+	is.True(errors.Is(err, ErrInterrupted))
+	is.True(isCooked(t, tty))
+}
+
+func TestConfirmKeyTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	output := new(bytes.Buffer)
+	prompt := New(output, tty)
+	go pressKey(t, ptmx, tty, "x?Y")
+	ok, err := prompt.ConfirmKey(ctx, "Continue?", false)
+	is.NoErr(err)
+	is.True(ok)
+	is.True(isCooked(t, tty))
+	go pressKey(t, ptmx, tty, "\r")
+	ok, err = prompt.ConfirmKey(ctx, "Continue?", false)
+	is.NoErr(err)
+	is.True(!ok)
+	go pressKey(t, ptmx, tty, "\x03")
+	_, err = prompt.ConfirmKey(ctx, "Continue?", true)
+	is.True(errors.Is(err, ErrInterrupted))
+	is.Equal(output.String(), "Continue? [y/N] y\r\nContinue? [y/N] n\r\nContinue? [Y/n] \r\n")
+}
+
+func TestHiddenTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	// term.ReadPassword reads the file descriptor directly, so it needs to be
+	// in blocking mode
+	is.NoErr(unix.SetNonblock(int(tty.Fd()), false))
+	output := new(bytes.Buffer)
+	prompt := New(output, tty)
+	go pressKey(t, ptmx, tty, "tok_123\r")
+	token, err := prompt.Hidden(true).Ask(ctx, "Token?")
+	is.NoErr(err)
+	is.Equal(token, "tok_123")
+	is.True(isCooked(t, tty))
+	is.Equal(output.String(), "Token? \n")
+}
+
+func TestPasswordInvalidUTF8Terminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	is.NoErr(unix.SetNonblock(int(tty.Fd()), false))
+	prompt := New(io.Discard, tty)
+	go pressKey(t, ptmx, tty, "a\xffb\r")
+	pass, err := prompt.Password(ctx, "Password?")
+	is.NoErr(err)
+	is.Equal(pass, "a�b")
+}
+
+func TestIdleTimeoutTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	prompt := New(io.Discard, tty).LineEditing(true)
+	// Typing slowly is fine as long as each key arrives in time
+	go func() {
+		pressKey(t, ptmx, tty, "A")
+		for _, key := range []string{"l", "i", "c", "e", "\r"} {
+			time.Sleep(40 * time.Millisecond)
+			ptmx.Write([]byte(key))
+		}
+	}()
+	name, err := prompt.IdleTimeout(150*time.Millisecond).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	// Stopping mid-answer times out
+	go pressKey(t, ptmx, tty, "Bo")
+	name, err = prompt.IdleTimeout(50*time.Millisecond).Ask(ctx, "What is your name?")
+	is.True(errors.Is(err, ErrTimeout))
+	is.Equal(name, "")
+	is.True(isCooked(t, tty))
+}
+
+func TestAskRuneTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	output := new(bytes.Buffer)
+	prompt := New(output, tty)
+	go pressKey(t, ptmx, tty, "xs")
+	key, err := prompt.AskRune(ctx, "Action?", []rune{'s', 'q'})
+	is.NoErr(err)
+	is.Equal(key, 's')
+	is.True(isCooked(t, tty))
+	go pressKey(t, ptmx, tty, "\r")
+	key, err = prompt.Default("q").AskRune(ctx, "Action?", []rune{'s', 'q'})
+	is.NoErr(err)
+	is.Equal(key, 'q')
+	is.Equal(output.String(), "Action? x\r\ninvalid key \"x\", must be one of s, q\nAction? s\r\nAction? [q] \r\n")
+}
+
+func TestCountdownTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	output := new(bytes.Buffer)
+	prompt := New(output, tty).Default("prod")
+	env, err := prompt.Countdown(ctx, "Deploy to?", 50*time.Millisecond)
+	is.NoErr(err)
+	is.Equal(env, "prod")
+	is.True(isCooked(t, tty))
+	is.Equal(output.String(), "Deploy to? [prod] (1s)\x1b[K\x1b[4D\x1b[K\n")
+	// Typing stops the countdown
+	output.Reset()
+	go pressKey(t, ptmx, tty, "staging\r")
+	env, err = prompt.Countdown(ctx, "Deploy to?", time.Second)
+	is.NoErr(err)
+	is.Equal(env, "staging")
+	is.True(isCooked(t, tty))
+	is.Equal(output.String(), "Deploy to? [prod] (1s)\x1b[K\x1b[4D\x1b[Ks")
+	// Enter uses the default
+	go pressKey(t, ptmx, tty, "\r")
+	env, err = prompt.Countdown(ctx, "Deploy to?", time.Second)
+	is.NoErr(err)
+	is.Equal(env, "prod")
+}
+
+func TestAskFixedTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	output := new(bytes.Buffer)
+	prompt := New(output, tty)
+	go pressKey(t, ptmx, tty, "1234")
+	pin, err := prompt.Mask('*').AskFixed(ctx, "PIN?", 4)
+	is.NoErr(err)
+	is.Equal(pin, "1234")
+	is.True(isCooked(t, tty))
+	is.Equal(output.String(), "PIN? ****\r\n")
+}
+
+func TestWrapTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	is.NoErr(unix.IoctlSetWinsize(int(ptmx.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Row: 24, Col: 20}))
+	output := new(bytes.Buffer)
+	prompt := New(output, tty).Wrap(true)
+	ptmx.Write([]byte("y\n"))
+	answer, err := prompt.Ask(ctx, "This will delete every file in the bucket. Continue?")
+	is.NoErr(err)
+	is.Equal(answer, "y")
+	is.Equal(output.String(), "This will delete\nevery file in the\nbucket. Continue? ")
+}
+
+func TestEditableClearedTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	prompt := New(io.Discard, tty)
+	// Ctrl-U clears the default that was typed in
+	go pressKey(t, ptmx, tty, "\x15\r")
+	name, err := prompt.Default("Alice").Editable(true).Optional(true).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "")
+	go pressKey(t, ptmx, tty, "\x15\r")
+	_, err = prompt.Default("Alice").Editable(true).Once().Ask(ctx, "Name?")
+	is.True(errors.Is(err, ErrRequired))
+	// Leaving the default alone uses it
+	go pressKey(t, ptmx, tty, "\r")
+	name, err = prompt.Default("Alice").Editable(true).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	is.True(isCooked(t, tty))
+}
+
+func TestEditableTimeoutTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	_, tty := openPty(t)
+	prompt := New(io.Discard, tty)
+	// The default is used when nothing is entered in time
+	name, err := prompt.Default("Alice").Editable(true).Timeout(10*time.Millisecond).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	is.True(isCooked(t, tty))
+}
+
+func TestBellTerminal(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	ptmx, tty := openPty(t)
+	prompt := New(tty, tty)
+	ptmx.Write([]byte("Al\nAlice\n"))
+	name, err := prompt.Bell(true).Is(func(s string) error {
+		if len(s) < 3 {
+			return errors.New("too short")
+		}
+		return nil
+	}).Ask(ctx, "Name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+	// The terminal echoes the input along with the prompts
+	is.NoErr(ptmx.SetReadDeadline(time.Now().Add(time.Second)))
+	var output []byte
+	buf := make([]byte, 1024)
+	for !bytes.Contains(output, []byte("too short\r\n\aName? ")) {
+		n, err := ptmx.Read(buf)
+		is.NoErr(err)
+		output = append(output, buf[:n]...)
+	}
+}
@@ -0,0 +1,114 @@
+package prompter
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+)
+
+func TestReadMasked(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	reader := strings.NewReader("pasz\x7fs\r")
+	pass, err := readRunes(reader, writer, '*', 0, 0)
+	is.NoErr(err)
+	is.Equal(pass, "pass")
+	diff.TestString(t, writer.String(), "****\b \b*")
+}
+
+func TestReadMaskedEmptyBackspace(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	reader := strings.NewReader("\x7f\bok\x01\n")
+	pass, err := readRunes(reader, writer, '•', 0, 0)
+	is.NoErr(err)
+	is.Equal(pass, "ok")
+	diff.TestString(t, writer.String(), "••")
+}
+
+func TestReadMaskedInterrupted(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	reader := strings.NewReader("pa\x03ss\r")
+	pass, err := readRunes(reader, writer, '*', 0, 0)
+	is.True(errors.Is(err, ErrInterrupted))
+	is.Equal(pass, "")
+	diff.TestString(t, writer.String(), "**\r\n")
+}
+
+func TestSuggestionsComplete(t *testing.T) {
+	is := is.New(t)
+	q := newQuestion(New(io.Discard, strings.NewReader(""))).Suggestions("us-east-1", "us-west-2", "eu-west-1")
+	complete := q.completer()
+	is.Equal(complete("us-"), []string{"us-east-1", "us-west-2"})
+	is.Equal(complete("eu"), []string{"eu-west-1"})
+	is.Equal(complete("ap"), nil)
+	q.Complete(func(prefix string) []string { return []string{"custom"} })
+	is.Equal(q.completer()("us"), []string{"custom"})
+}
+
+func TestReadMaskedWide(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	reader := strings.NewReader("ab\x7f\r")
+	pass, err := readRunes(reader, writer, '＊', 0, 0)
+	is.NoErr(err)
+	is.Equal(pass, "a")
+	diff.TestString(t, writer.String(), "＊＊\b\b  \b\b")
+}
+
+func TestRunesWidth(t *testing.T) {
+	is := is.New(t)
+	is.Equal(runesWidth([]rune("Mark")), 4)
+	is.Equal(runesWidth([]rune("日本語")), 6)
+	is.Equal(runesWidth([]rune("한국어")), 6)
+	is.Equal(runesWidth([]rune("ｆｕｌｌ")), 8)
+	is.Equal(runesWidth([]rune("é")), 1)
+	is.Equal(runesWidth([]rune("🎉")), 2)
+}
+
+func TestReadRevealed(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	reader := strings.NewReader("ab\x7fc\r")
+	pass, err := readRunes(reader, writer, '*', time.Hour, 0)
+	is.NoErr(err)
+	is.Equal(pass, "ac")
+	diff.TestString(t, writer.String(), "a\x1b[1D*b\x1b[1D*\b \bc\x1b[1D*")
+}
+
+func TestReadRevealedTimer(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	reader, pipe := io.Pipe()
+	go func() {
+		pipe.Write([]byte("日"))
+		time.Sleep(50 * time.Millisecond)
+		pipe.Write([]byte("\r"))
+	}()
+	pass, err := readRunes(bufio.NewReader(reader), writer, '*', time.Millisecond, 0)
+	is.NoErr(err)
+	is.Equal(pass, "日")
+	diff.TestString(t, writer.String(), "日\x1b[2D* \x1b[1D")
+}
+
+func TestReadRunesFixed(t *testing.T) {
+	is := is.New(t)
+	writer := new(bytes.Buffer)
+	reader := strings.NewReader("12日\x7f34")
+	pin, err := readRunes(reader, writer, 0, 0, 4)
+	is.NoErr(err)
+	is.Equal(pin, "1234")
+	diff.TestString(t, writer.String(), "12日\b\b  \b\b34")
+	// Enter submits early
+	pin, err = readRunes(strings.NewReader("12\r34"), io.Discard, '*', 0, 4)
+	is.NoErr(err)
+	is.Equal(pin, "12")
+}
@@ -0,0 +1,89 @@
+package prompter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Theme formats the prompts and error messages. Empty fields fall back to
+// the default formatting, so the zero value formats like an unthemed prompt.
+type Theme struct {
+	// PromptSuffix is written after the prompt. Defaults to a space. Use
+	// Suffix for no suffix at all.
+	PromptSuffix string
+	// ErrorPrefix is written before error messages
+	ErrorPrefix string
+	// DefaultFormat formats the default value shown after the prompt. Defaults
+	// to wrapping the value in brackets.
+	DefaultFormat func(defaultTo string) string
+	// PromptColor colors the prompt
+	PromptColor func(string) string
+	// ErrorColor colors error messages
+	ErrorColor func(string) string
+	// DisabledColor colors disabled options. Defaults to dimming them.
+	DisabledColor func(string) string
+}
+
+// WithTheme sets the theme used to format prompts and error messages. A nil
+// theme resets the formatting.
+func (p *Prompt) WithTheme(theme *Theme) *Prompt {
+	if theme == nil {
+		p.theme = Theme{}
+		return p
+	}
+	p.theme = *theme
+	return p
+}
+
+// Suffix sets what's written after each prompt, in place of the theme's
+// PromptSuffix. Unlike the theme, an empty suffix leaves the cursor right
+// after the prompt, like after a prompt that ends in "> ".
+func (p *Prompt) Suffix(suffix string) *Prompt {
+	p.suffix = &suffix
+	return p
+}
+
+// dim colors disabled options
+func (p *Prompt) dim(s string) string {
+	if !p.colorful() {
+		return s
+	} else if p.theme.DisabledColor == nil {
+		return "\x1b[2m" + s + "\x1b[0m"
+	}
+	return p.theme.DisabledColor(s)
+}
+
+func (p *Prompt) promptSuffix() string {
+	if p.suffix != nil {
+		return *p.suffix
+	} else if p.theme.PromptSuffix == "" {
+		return " "
+	}
+	return p.theme.PromptSuffix
+}
+
+func (p *Prompt) formatDefault(defaultTo string) string {
+	if p.theme.DefaultFormat == nil {
+		return "[" + defaultTo + "]"
+	}
+	return p.theme.DefaultFormat(defaultTo)
+}
+
+// printError writes an error message on its own line. Each line of errors
+// with more than one line, like joined errors, is written the same way.
+// Writing fails when the output has gone away or the error renderer fails.
+func (p *Prompt) printError(err error) error {
+	for _, line := range strings.Split(err.Error(), "\n") {
+		line = p.colorError(p.theme.ErrorPrefix + line)
+		if p.renderError != nil {
+			if err := p.renderError(line); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintln(p.writer, line); err != nil {
+			return fmt.Errorf("prompter: unable to write the error: %w", err)
+		}
+	}
+	return nil
+}
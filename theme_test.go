@@ -0,0 +1,61 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestWithTheme(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nAm\nAmy\n")
+	prompt := prompter.New(writer, reader).WithTheme(&prompter.Theme{
+		PromptSuffix: " > ",
+		ErrorPrefix:  "✗ ",
+		DefaultFormat: func(defaultTo string) string {
+			return "(default: " + defaultTo + ")"
+		},
+	})
+	age, err := prompt.Default("21").Ask(ctx, "What is your age?")
+	is.NoErr(err)
+	is.Equal(age, "21")
+	name, err := prompt.Is(prompter.MinLength(3)).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Amy")
+	diff.TestString(t, writer.String(), "What is your age? (default: 21) > What is your name? > ✗ must be at least 3 characters, got 2\nWhat is your name? > ")
+}
+
+func TestWithThemeZero(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(writer, reader).WithTheme(&prompter.Theme{})
+	age, err := prompt.Default("21").Ask(ctx, "What is your age?")
+	is.NoErr(err)
+	is.Equal(age, "21")
+	diff.TestString(t, writer.String(), "What is your age? [21] ")
+}
+
+func TestSuffix(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("Amy\nBob\n")
+	prompt := prompter.New(writer, reader).Suffix("")
+	name, err := prompt.Ask(ctx, "> ")
+	is.NoErr(err)
+	is.Equal(name, "Amy")
+	// The suffix takes precedence over the theme
+	prompt.WithTheme(&prompter.Theme{PromptSuffix: " > "}).Suffix(": ")
+	name, err = prompt.Ask(ctx, "Name")
+	is.NoErr(err)
+	is.Equal(name, "Bob")
+	diff.TestString(t, writer.String(), "> Name: ")
+}
@@ -0,0 +1,70 @@
+package prompter
+
+import (
+	"context"
+	"strings"
+)
+
+// TrimMode controls how whitespace is trimmed from the input
+type TrimMode int
+
+const (
+	// TrimNewline trims any trailing newlines and carriage returns. This is the
+	// default.
+	TrimNewline TrimMode = iota
+	// TrimNone only removes the line ending, preserving the input exactly as it
+	// was typed
+	TrimNone
+	// TrimSpace trims all leading and trailing whitespace
+	TrimSpace
+)
+
+// Trim sets how whitespace is trimmed from the input
+func (p *Prompt) Trim(mode TrimMode) *Question {
+	q := newQuestion(p)
+	q.trim = mode
+	return q
+}
+
+// Trim sets how whitespace is trimmed from the input. Trimming happens before
+// the transforms run.
+func (q *Question) Trim(mode TrimMode) *Question {
+	q.trim = mode
+	return q
+}
+
+// AskRaw asks a question and returns the input exactly as it was typed
+func (p *Prompt) AskRaw(ctx context.Context, prompt string) (string, error) {
+	q := newQuestion(p)
+	return q.AskRaw(ctx, prompt)
+}
+
+// AskRaw asks a question and returns the input exactly as it was typed, with
+// only the line ending removed, whatever the Trim mode. Whitespace-only input
+// isn't replaced by the default either, so only an empty line uses the
+// default. Validators, transforms and the default still apply.
+func (q *Question) AskRaw(ctx context.Context, prompt string) (string, error) {
+	defer func(trim TrimMode, blank bool) {
+		q.trim, q.defaultOnBlank = trim, blank
+	}(q.trim, q.defaultOnBlank)
+	q.trim, q.defaultOnBlank = TrimNone, false
+	return q.Ask(ctx, prompt)
+}
+
+// trimLineEnding trims the line ending from a line that was read
+func (q *Question) trimLineEnding(line string) string {
+	if q.trim == TrimNone {
+		line = strings.TrimSuffix(line, "\n")
+		return strings.TrimSuffix(line, "\r")
+	}
+	return strings.TrimRight(line, "\r\n")
+}
+
+// trimSpace trims the whitespace around the input when trimming spaces. Blank
+// input is trimmed to nothing when it should use the default.
+func (q *Question) trimSpace(input string) string {
+	if q.trim == TrimSpace || (q.defaultOnBlank && q.hasDefault() && strings.TrimSpace(input) == "") {
+		return strings.TrimSpace(input)
+	}
+	return input
+}
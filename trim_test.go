@@ -0,0 +1,67 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestTrimNewline(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("  Alice \r\n")
+	prompt := prompter.New(os.Stdout, reader)
+	name, err := prompt.Trim(prompter.TrimNewline).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "  Alice ")
+}
+
+func TestTrimNone(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("  Alice \t\r\n top secret \n")
+	prompt := prompter.New(os.Stdout, reader)
+	name, err := prompt.Trim(prompter.TrimNone).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "  Alice \t")
+	pass, err := prompt.Trim(prompter.TrimNone).Password(ctx, "What is your password?")
+	is.NoErr(err)
+	is.Equal(pass, " top secret ")
+}
+
+func TestTrimSpace(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString(" \t \n  Alice \r\n")
+	prompt := prompter.New(os.Stdout, reader)
+	name, err := prompt.Trim(prompter.TrimSpace).Ask(ctx, "What is your name?")
+	is.NoErr(err)
+	is.Equal(name, "Alice")
+}
+
+func TestTrimSpaceValidate(t *testing.T) {
+	is := is.New(t)
+	prompt := prompter.New(os.Stdout, bytes.NewBufferString(""))
+	question := prompt.Trim(prompter.TrimSpace).Is(prompter.OneOf("dev", "prod"))
+	is.NoErr(question.Validate(" dev "))
+	is.True(errors.Is(question.Validate("  "), prompter.ErrRequired))
+}
+
+func TestAskRaw(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("  PING \t\r\n\n")
+	prompt := prompter.New(os.Stdout, reader)
+	line, err := prompt.Trim(prompter.TrimSpace).AskRaw(ctx, "Command?")
+	is.NoErr(err)
+	is.Equal(line, "  PING \t")
+	// An empty line still uses the default
+	line, err = prompt.Default("PONG").AskRaw(ctx, "Command?")
+	is.NoErr(err)
+	is.Equal(line, "PONG")
+}
@@ -0,0 +1,35 @@
+package prompter
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrRejected is returned when an AskUntil check rejects the input and the
+// question won't be asked again
+var ErrRejected = fmt.Errorf("prompter: input rejected")
+
+// AskUntil asks until ok accepts the input
+func (p *Prompt) AskUntil(ctx context.Context, prompt string, ok func(input string) (bool, error)) (string, error) {
+	q := newQuestion(p)
+	return q.AskUntil(ctx, prompt, ok)
+}
+
+// AskUntil asks until ok accepts the input. Returning false asks again and
+// returning an error stops asking and returns the error. Unlike validators,
+// ok is meant for checks with side effects, like calling an API, so it only
+// runs after the input has passed the validators.
+func (q *Question) AskUntil(ctx context.Context, prompt string, ok func(input string) (bool, error)) (string, error) {
+	q.until = ok
+	defer func() { q.until = nil }()
+	return q.Ask(ctx, prompt)
+}
+
+// checkUntil runs the AskUntil check on valid input. A nil error with false
+// means the input was rejected.
+func (q *Question) checkUntil(input string) (bool, error) {
+	if q.until == nil {
+		return true, nil
+	}
+	return q.until(input)
+}
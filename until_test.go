@@ -0,0 +1,72 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskUntil(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("taken\nfree\n")
+	prompt := prompter.New(writer, reader)
+	var checked []string
+	name, err := prompt.AskUntil(ctx, "Username?", func(input string) (bool, error) {
+		checked = append(checked, input)
+		return input == "free", nil
+	})
+	is.NoErr(err)
+	is.Equal(name, "free")
+	is.Equal(checked, []string{"taken", "free"})
+	diff.TestString(t, writer.String(), "Username? Username? ")
+}
+
+func TestAskUntilError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("taken\nfree\n")
+	prompt := prompter.New(new(bytes.Buffer), reader)
+	errUnavailable := errors.New("service unavailable")
+	name, err := prompt.AskUntil(ctx, "Username?", func(input string) (bool, error) {
+		return false, errUnavailable
+	})
+	is.True(errors.Is(err, errUnavailable))
+	is.Equal(name, "")
+}
+
+func TestAskUntilValidators(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("\nab\nabc\n")
+	prompt := prompter.New(writer, reader)
+	var checked []string
+	name, err := prompt.Is(prompter.MinLength(3)).AskUntil(ctx, "Username?", func(input string) (bool, error) {
+		checked = append(checked, input)
+		return true, nil
+	})
+	is.NoErr(err)
+	is.Equal(name, "abc")
+	is.Equal(checked, []string{"abc"})
+	diff.TestString(t, writer.String(), "Username? Username? must be at least 3 characters, got 2\nUsername? ")
+}
+
+func TestAskUntilMaxAttempts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("a\nb\nc\n")
+	prompt := prompter.New(new(bytes.Buffer), reader)
+	name, err := prompt.MaxAttempts(2).AskUntil(ctx, "Username?", func(input string) (bool, error) {
+		return false, nil
+	})
+	is.True(errors.Is(err, prompter.ErrTooManyAttempts))
+	is.True(errors.Is(err, prompter.ErrRejected))
+	is.Equal(name, "")
+}
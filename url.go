@@ -0,0 +1,39 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// AskURL asks for an absolute URL and returns it
+func (p *Prompt) AskURL(ctx context.Context, prompt string) (*url.URL, error) {
+	q := newQuestion(p)
+	return q.AskURL(ctx, prompt)
+}
+
+// AskURL asks for an absolute URL and returns it. URLs are parsed with
+// url.ParseRequestURI and must have a scheme, so relative URLs are rejected.
+// Validators like URLScheme run against the input before it's parsed. nil is
+// returned when the question is optional and nothing was entered.
+func (q *Question) AskURL(ctx context.Context, prompt string) (*url.URL, error) {
+	return askParse(ctx, q, prompt, func(s string) (*url.URL, error) {
+		u, err := parseURL(s)
+		if err != nil {
+			return nil, errors.New("please enter a URL like https://example.com")
+		}
+		return u, nil
+	})
+}
+
+// parseURL parses an absolute URL
+func parseURL(s string) (*url.URL, error) {
+	u, err := url.ParseRequestURI(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	} else if u.Scheme == "" {
+		return nil, errors.New("missing scheme")
+	}
+	return u, nil
+}
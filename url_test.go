@@ -0,0 +1,69 @@
+package prompter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/diff"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestAskURL(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("example.com\n/hooks\nhttps://example.com/hooks?id=1\n")
+	prompt := prompter.New(writer, reader)
+	u, err := prompt.AskURL(ctx, "Webhook?")
+	is.NoErr(err)
+	is.Equal(u.Host, "example.com")
+	is.Equal(u.Path, "/hooks")
+	diff.TestString(t, writer.String(), "Webhook? please enter a URL like https://example.com\nWebhook? please enter a URL like https://example.com\nWebhook? ")
+}
+
+func TestAskURLScheme(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	writer := new(bytes.Buffer)
+	reader := bytes.NewBufferString("ftp://example.com\nHTTPS://example.com\n")
+	prompt := prompter.New(writer, reader)
+	u, err := prompt.Is(prompter.URLScheme("http", "https")).AskURL(ctx, "Webhook?")
+	is.NoErr(err)
+	is.Equal(u.String(), "https://example.com")
+	diff.TestString(t, writer.String(), "Webhook? invalid scheme \"ftp\", must be one of http, https\nWebhook? ")
+}
+
+func TestAskURLDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	u, err := prompt.Default("http://localhost:3000").AskURL(ctx, "Webhook?")
+	is.NoErr(err)
+	is.Equal(u.Host, "localhost:3000")
+}
+
+func TestAskURLOptional(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("\n")
+	prompt := prompter.New(os.Stdout, reader)
+	u, err := prompt.Optional(true).AskURL(ctx, "Webhook?")
+	is.NoErr(err)
+	is.Equal(u, (*url.URL)(nil))
+}
+
+func TestAskURLErrRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	reader := bytes.NewBufferString("")
+	prompt := prompter.New(os.Stdout, reader)
+	u, err := prompt.AskURL(ctx, "Webhook?")
+	is.True(errors.Is(err, prompter.ErrRequired))
+	is.Equal(u, (*url.URL)(nil))
+}
@@ -0,0 +1,340 @@
+package prompter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Email validates that the input is an email address. Addresses with display
+// names like "Mark <mark@example.com>" are also accepted.
+func Email() func(string) error {
+	return func(s string) error {
+		if _, err := mail.ParseAddress(s); err != nil {
+			return fmt.Errorf("%q is not a valid email address", s)
+		}
+		return nil
+	}
+}
+
+// EmailAddrOnly validates that the input is an email address without a
+// display name
+func EmailAddrOnly() func(string) error {
+	return func(s string) error {
+		addr, err := mail.ParseAddress(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid email address", s)
+		} else if addr.Name != "" || addr.Address != strings.TrimSpace(s) {
+			return fmt.Errorf("%q must be an email address without a name", s)
+		}
+		return nil
+	}
+}
+
+// lookupMX looks up the mail servers for a domain
+var lookupMX = net.DefaultResolver.LookupMX
+
+// mxTimeout limits how long EmailDeliverable waits for each lookup
+const mxTimeout = 5 * time.Second
+
+// EmailDeliverable validates that the domain of the email address accepts
+// mail by looking up its MX records. Unlike the other validators, this calls
+// out to DNS every time an answer is checked, which usually takes a few
+// milliseconds but can take seconds on slow networks. Each lookup gives up
+// after 5 seconds. Use it after Email so typos are caught without the
+// network.
+func EmailDeliverable() func(string) error {
+	return EmailDeliverableContext(context.Background())
+}
+
+// EmailDeliverableContext is like EmailDeliverable, but lookups stop when ctx
+// is cancelled. Pass the context given to Ask so a cancelled prompt doesn't
+// wait on DNS.
+func EmailDeliverableContext(ctx context.Context) func(string) error {
+	return func(s string) error {
+		addr, err := mail.ParseAddress(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid email address", s)
+		}
+		domain := addr.Address[strings.LastIndex(addr.Address, "@")+1:]
+		lookupCtx, cancel := context.WithTimeout(ctx, mxTimeout)
+		defer cancel()
+		records, err := lookupMX(lookupCtx, domain)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				return fmt.Errorf("%q doesn't accept email", domain)
+			}
+			return fmt.Errorf("unable to check if %q accepts email: %w", domain, err)
+		}
+		// A single "." record means the domain doesn't accept email
+		if len(records) == 0 || (len(records) == 1 && records[0].Host == ".") {
+			return fmt.Errorf("%q doesn't accept email", domain)
+		}
+		return nil
+	}
+}
+
+// MinLength validates that the input has at least n characters
+func MinLength(n int) func(string) error {
+	return func(s string) error {
+		if length := utf8.RuneCountInString(s); length < n {
+			return fmt.Errorf("must be at least %d characters, got %d", n, length)
+		}
+		return nil
+	}
+}
+
+// MaxLength validates that the input has at most n characters
+func MaxLength(n int) func(string) error {
+	return func(s string) error {
+		if length := utf8.RuneCountInString(s); length > n {
+			return fmt.Errorf("must be at most %d characters, got %d", n, length)
+		}
+		return nil
+	}
+}
+
+// Match validates that the input matches the regular expression, returning an
+// error with the message if it doesn't
+func Match(re *regexp.Regexp, msg string) func(string) error {
+	return func(s string) error {
+		if !re.MatchString(s) {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// MatchString is like Match but compiles the pattern first. It panics if the
+// pattern is invalid.
+func MatchString(pattern, msg string) func(string) error {
+	return Match(regexp.MustCompile(pattern), msg)
+}
+
+// OneOf validates that the input is one of the allowed values
+func OneOf(allowed ...string) func(string) error {
+	return func(s string) error {
+		for _, value := range allowed {
+			if s == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid value %q, must be one of %s", s, strings.Join(allowed, ", "))
+	}
+}
+
+// OneOfFold validates that the input is one of the allowed values, ignoring
+// case
+func OneOfFold(allowed ...string) func(string) error {
+	return func(s string) error {
+		if containsFold(allowed, s) {
+			return nil
+		}
+		return fmt.Errorf("invalid value %q, must be one of %s", s, strings.Join(allowed, ", "))
+	}
+}
+
+// IntRange validates that the input is a whole number between min and max,
+// inclusive
+func IntRange(min, max int) func(string) error {
+	return func(s string) error {
+		n, err := parseInt(s)
+		if err != nil {
+			return errors.New("please enter a whole number")
+		} else if n < min || n > max {
+			return fmt.Errorf("must be between %d and %d, got %d", min, max, n)
+		}
+		return nil
+	}
+}
+
+// DurationRange validates that the input is a duration between min and max,
+// inclusive. Use a min of 0 to reject negative durations.
+func DurationRange(min, max time.Duration) func(string) error {
+	return func(s string) error {
+		d, err := parseDuration(s)
+		if err != nil {
+			return errors.New("please enter a duration like 1m30s")
+		} else if d < min || d > max {
+			return fmt.Errorf("must be between %s and %s, got %s", min, max, d)
+		}
+		return nil
+	}
+}
+
+// IP validates that the input is an IPv4 or IPv6 address
+func IP() func(string) error {
+	return func(s string) error {
+		if parseIP(s) == nil {
+			return fmt.Errorf("%q is not a valid IP address", s)
+		}
+		return nil
+	}
+}
+
+// CIDR validates that the input is an IP address and prefix length in CIDR
+// notation, like 192.168.0.0/16
+func CIDR() func(string) error {
+	return func(s string) error {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(s)); err != nil {
+			return fmt.Errorf("%q is not a valid CIDR", s)
+		}
+		return nil
+	}
+}
+
+// URLScheme validates that the input is an absolute URL with one of the
+// allowed schemes, like http or https. Schemes are matched ignoring case.
+func URLScheme(schemes ...string) func(string) error {
+	return func(s string) error {
+		u, err := parseURL(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid URL", s)
+		} else if !containsFold(schemes, u.Scheme) {
+			return fmt.Errorf("invalid scheme %q, must be one of %s", u.Scheme, strings.Join(schemes, ", "))
+		}
+		return nil
+	}
+}
+
+// FileExists validates that the input is the path of an existing file. A
+// leading ~ is expanded to the user's home directory.
+func FileExists() func(string) error {
+	return func(s string) error {
+		info, err := statPath(s)
+		if err != nil {
+			return err
+		} else if info.IsDir() {
+			return fmt.Errorf("%q is a directory, not a file", s)
+		}
+		return nil
+	}
+}
+
+// DirExists validates that the input is the path of an existing directory. A
+// leading ~ is expanded to the user's home directory.
+func DirExists() func(string) error {
+	return func(s string) error {
+		info, err := statPath(s)
+		if err != nil {
+			return err
+		} else if !info.IsDir() {
+			return fmt.Errorf("%q is not a directory", s)
+		}
+		return nil
+	}
+}
+
+// statPath expands and stats the path, returning an error message suitable for
+// the user if it doesn't exist
+func statPath(s string) (fs.FileInfo, error) {
+	path, err := expandHome(s)
+	if err != nil {
+		return nil, fmt.Errorf("unable to expand %q: %w", s, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%q does not exist", s)
+		}
+		return nil, fmt.Errorf("unable to check %q: %w", s, err)
+	}
+	return info, nil
+}
+
+// PathAbsolute validates that the input is an absolute path. Paths starting
+// with ~ are absolute once expanded to the user's home directory.
+func PathAbsolute() func(string) error {
+	return func(s string) error {
+		path, err := expandHome(s)
+		if err != nil {
+			return fmt.Errorf("unable to expand %q: %w", s, err)
+		} else if !filepath.IsAbs(path) {
+			return fmt.Errorf("%q must be an absolute path", s)
+		}
+		return nil
+	}
+}
+
+// EqualTo validates that the input matches the secret. The comparison takes
+// constant time, so it's safe to use with secrets like tokens.
+func EqualTo(secret string) func(string) error {
+	return func(s string) error {
+		if !secretEqual(s, secret) {
+			return errors.New("does not match")
+		}
+		return nil
+	}
+}
+
+// StrengthOpts are the requirements checked by PasswordStrength. Zero values
+// aren't checked.
+type StrengthOpts struct {
+	// MinLength is the minimum number of characters
+	MinLength int
+	// MinUpper is the minimum number of uppercase letters
+	MinUpper int
+	// MinLower is the minimum number of lowercase letters
+	MinLower int
+	// MinDigits is the minimum number of digits
+	MinDigits int
+	// MinSymbols is the minimum number of punctuation marks and symbols
+	MinSymbols int
+}
+
+// PasswordStrength validates that the input meets the strength requirements.
+// Characters are classified using Unicode, so letters like É count as
+// uppercase and digits from other scripts count as digits. The error explains
+// the first requirement that isn't met.
+func PasswordStrength(opts StrengthOpts) func(string) error {
+	return func(s string) error {
+		var length, upper, lower, digits, symbols int
+		for _, r := range s {
+			length++
+			switch {
+			case unicode.IsUpper(r):
+				upper++
+			case unicode.IsLower(r):
+				lower++
+			case unicode.IsDigit(r):
+				digits++
+			case unicode.IsPunct(r) || unicode.IsSymbol(r):
+				symbols++
+			}
+		}
+		switch {
+		case length < opts.MinLength:
+			return fmt.Errorf("needs at least %d characters", opts.MinLength)
+		case upper < opts.MinUpper:
+			return needsAtLeast(opts.MinUpper, "uppercase letter", "uppercase letters")
+		case lower < opts.MinLower:
+			return needsAtLeast(opts.MinLower, "lowercase letter", "lowercase letters")
+		case digits < opts.MinDigits:
+			return needsAtLeast(opts.MinDigits, "digit", "digits")
+		case symbols < opts.MinSymbols:
+			return needsAtLeast(opts.MinSymbols, "symbol", "symbols")
+		}
+		return nil
+	}
+}
+
+func needsAtLeast(n int, singular, plural string) error {
+	if n == 1 {
+		return fmt.Errorf("needs at least one %s", singular)
+	}
+	return fmt.Errorf("needs at least %d %s", n, plural)
+}
@@ -0,0 +1,245 @@
+package prompter_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/prompter"
+)
+
+func TestEmail(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.Email()
+	is.NoErr(validate("mark@example.com"))
+	is.NoErr(validate("mark.mueller+test@sub.example.co.uk"))
+	is.NoErr(validate("Mark <mark@example.com>"))
+	is.True(validate("") != nil)
+	is.True(validate("mark") != nil)
+	is.True(validate("mark@") != nil)
+	is.True(validate("@example.com") != nil)
+	is.True(validate("mark@@example.com") != nil)
+	is.True(validate("mark example.com") != nil)
+	is.Equal(validate("mark").Error(), `"mark" is not a valid email address`)
+}
+
+func TestEmailAddrOnly(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.EmailAddrOnly()
+	is.NoErr(validate("mark@example.com"))
+	is.True(validate("mark") != nil)
+	is.True(validate("<mark@example.com>") != nil)
+	err := validate("Mark <mark@example.com>")
+	is.True(err != nil)
+	is.Equal(err.Error(), `"Mark <mark@example.com>" must be an email address without a name`)
+}
+
+func TestMinLength(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.MinLength(3)
+	is.NoErr(validate("Amy"))
+	is.NoErr(validate("Zoë"))
+	is.NoErr(validate("日本語"))
+	err := validate("Am")
+	is.True(err != nil)
+	is.Equal(err.Error(), "must be at least 3 characters, got 2")
+}
+
+func TestMaxLength(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.MaxLength(3)
+	is.NoErr(validate(""))
+	is.NoErr(validate("Zoë"))
+	is.NoErr(validate("日本語"))
+	err := validate("Mark")
+	is.True(err != nil)
+	is.Equal(err.Error(), "must be at most 3 characters, got 4")
+}
+
+func TestMatch(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.Match(regexp.MustCompile(`^SKU-\d{4}$`), "must look like SKU-1234")
+	is.NoErr(validate("SKU-1234"))
+	err := validate("SKU-12")
+	is.True(err != nil)
+	is.Equal(err.Error(), "must look like SKU-1234")
+}
+
+func TestMatchString(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.MatchString(`^ord_[a-z0-9]+$`, "invalid order id")
+	is.NoErr(validate("ord_abc123"))
+	is.Equal(validate("order").Error(), "invalid order id")
+}
+
+func TestMatchStringInvalid(t *testing.T) {
+	is := is.New(t)
+	defer func() {
+		is.True(recover() != nil)
+	}()
+	prompter.MatchString(`(`, "unreachable")
+}
+
+func TestOneOf(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.OneOf("debug", "info", "warn", "error")
+	is.NoErr(validate("debug"))
+	is.NoErr(validate("error"))
+	is.True(validate("DEBUG") != nil)
+	is.Equal(validate("trace").Error(), `invalid value "trace", must be one of debug, info, warn, error`)
+}
+
+func TestOneOfFold(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.OneOfFold("json", "yaml")
+	is.NoErr(validate("json"))
+	is.NoErr(validate("YAML"))
+	is.Equal(validate("toml").Error(), `invalid value "toml", must be one of json, yaml`)
+}
+
+func TestIntRange(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.IntRange(1, 65535)
+	is.NoErr(validate("1"))
+	is.NoErr(validate("65535"))
+	is.NoErr(validate(" 8080 "))
+	is.Equal(validate("0").Error(), "must be between 1 and 65535, got 0")
+	is.Equal(validate("65536").Error(), "must be between 1 and 65535, got 65536")
+	is.Equal(validate("http").Error(), "please enter a whole number")
+	is.Equal(validate("").Error(), "please enter a whole number")
+}
+
+func TestIntRangeNegative(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.IntRange(-10, -5)
+	is.NoErr(validate("-10"))
+	is.NoErr(validate("-5"))
+	is.Equal(validate("-11").Error(), "must be between -10 and -5, got -11")
+	is.Equal(validate("-4").Error(), "must be between -10 and -5, got -4")
+	is.Equal(validate("5").Error(), "must be between -10 and -5, got 5")
+}
+
+func TestDurationRange(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.DurationRange(time.Second, time.Minute)
+	is.NoErr(validate("1s"))
+	is.NoErr(validate("1m"))
+	is.NoErr(validate("30s"))
+	is.Equal(validate("999ms").Error(), "must be between 1s and 1m0s, got 999ms")
+	is.Equal(validate("-1s").Error(), "must be between 1s and 1m0s, got -1s")
+	is.Equal(validate("61s").Error(), "must be between 1s and 1m0s, got 1m1s")
+	is.Equal(validate("soon").Error(), "please enter a duration like 1m30s")
+}
+
+func TestIP(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.IP()
+	is.NoErr(validate("10.0.0.1"))
+	is.NoErr(validate("::1"))
+	is.NoErr(validate("2001:db8::68"))
+	is.True(validate("") != nil)
+	is.True(validate("10.0.0") != nil)
+	is.True(validate("10.0.0.1/8") != nil)
+	is.Equal(validate("localhost").Error(), `"localhost" is not a valid IP address`)
+}
+
+func TestCIDR(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.CIDR()
+	is.NoErr(validate("192.168.0.0/16"))
+	is.NoErr(validate("2001:db8::/32"))
+	is.True(validate("") != nil)
+	is.True(validate("192.168.0.0") != nil)
+	is.True(validate("192.168.0.0/33") != nil)
+	is.Equal(validate("10.0.0.1").Error(), `"10.0.0.1" is not a valid CIDR`)
+}
+
+func TestURLScheme(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.URLScheme("http", "https")
+	is.NoErr(validate("http://example.com"))
+	is.NoErr(validate("https://example.com/path"))
+	is.True(validate("") != nil)
+	is.True(validate("/path") != nil)
+	is.Equal(validate("example.com").Error(), `"example.com" is not a valid URL`)
+	is.Equal(validate("ftp://example.com").Error(), `invalid scheme "ftp", must be one of http, https`)
+}
+
+func TestFileExists(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "go.mod")
+	is.NoErr(os.WriteFile(file, nil, 0644))
+	validate := prompter.FileExists()
+	is.NoErr(validate(file))
+	is.Equal(validate(dir).Error(), fmt.Sprintf("%q is a directory, not a file", dir))
+	missing := filepath.Join(dir, "missing")
+	is.Equal(validate(missing).Error(), fmt.Sprintf("%q does not exist", missing))
+}
+
+func TestDirExists(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "go.mod")
+	is.NoErr(os.WriteFile(file, nil, 0644))
+	validate := prompter.DirExists()
+	is.NoErr(validate(dir))
+	is.Equal(validate(file).Error(), fmt.Sprintf("%q is not a directory", file))
+	t.Setenv("HOME", dir)
+	is.NoErr(validate("~"))
+	is.Equal(validate("~/missing").Error(), `"~/missing" does not exist`)
+}
+
+func TestPathAbsolute(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.PathAbsolute()
+	is.NoErr(validate("/usr/local/bin"))
+	is.NoErr(validate("~/bin"))
+	is.Equal(validate("bin").Error(), `"bin" must be an absolute path`)
+	is.Equal(validate("./bin").Error(), `"./bin" must be an absolute path`)
+}
+
+func TestEqualTo(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.EqualTo("tok_abc")
+	is.NoErr(validate("tok_abc"))
+	is.Equal(validate("tok_ab").Error(), "does not match")
+	is.Equal(validate("tok_abd").Error(), "does not match")
+	is.Equal(validate("").Error(), "does not match")
+}
+
+func TestPasswordStrength(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.PasswordStrength(prompter.StrengthOpts{
+		MinLength:  8,
+		MinUpper:   1,
+		MinLower:   2,
+		MinDigits:  1,
+		MinSymbols: 2,
+	})
+	is.NoErr(validate("Secret1!?"))
+	is.Equal(validate("Sec1!?").Error(), "needs at least 8 characters")
+	is.Equal(validate("secret1!?").Error(), "needs at least one uppercase letter")
+	is.Equal(validate("SECRET1!?").Error(), "needs at least 2 lowercase letters")
+	is.Equal(validate("Secrets!?").Error(), "needs at least one digit")
+	is.Equal(validate("Secret12!").Error(), "needs at least 2 symbols")
+}
+
+func TestPasswordStrengthUnicode(t *testing.T) {
+	is := is.New(t)
+	validate := prompter.PasswordStrength(prompter.StrengthOpts{
+		MinLength:  6,
+		MinUpper:   1,
+		MinLower:   1,
+		MinDigits:  1,
+		MinSymbols: 1,
+	})
+	is.NoErr(validate("Éçà٣€x"))
+	is.Equal(validate("éçà٣€x").Error(), "needs at least one uppercase letter")
+	is.Equal(validate("Éçà€xy").Error(), "needs at least one digit")
+	is.NoErr(prompter.PasswordStrength(prompter.StrengthOpts{})(""))
+}
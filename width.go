@@ -0,0 +1,36 @@
+package prompter
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// runeWidth is the number of columns a rune takes up on a terminal. East
+// Asian wide and fullwidth runes take up two.
+func runeWidth(r rune) int {
+	if r == 0 || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// runesWidth is the number of columns the runes take up on a terminal
+func runesWidth(runes []rune) int {
+	n := 0
+	for _, r := range runes {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// validUTF8 replaces invalid UTF-8 in the input with the replacement
+// character, so validators always see valid text
+func validUTF8(s string) string {
+	return strings.ToValidUTF8(s, string(unicode.ReplacementChar))
+}
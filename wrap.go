@@ -0,0 +1,66 @@
+package prompter
+
+import (
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Wrap toggles wrapping long prompts to the width of the terminal, breaking
+// lines between words. Prompts aren't wrapped when the width is unknown, like
+// when writing to a pipe.
+func (p *Prompt) Wrap(enable bool) *Prompt {
+	p.wrap = enable
+	return p
+}
+
+// wrapWidth returns the width to wrap prompts at, or 0 when they shouldn't be
+// wrapped
+func (p *Prompt) wrapWidth() int {
+	if !p.wrap {
+		return 0
+	}
+	for _, fd := range []int{p.writerFd, p.fd} {
+		if fd < 0 || !term.IsTerminal(fd) {
+			continue
+		}
+		if width, _, err := term.GetSize(fd); err == nil && width > 0 {
+			return width
+		}
+	}
+	return 0
+}
+
+// wrapText breaks the lines of s between words so they fit within width
+// columns. Words that are wider than the width are left whole.
+func wrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine wraps a single line
+func wrapLine(line string, width int) string {
+	var b strings.Builder
+	column := 0
+	for i, word := range strings.Split(line, " ") {
+		cols := runesWidth([]rune(word))
+		switch {
+		case i == 0:
+		case column+1+cols > width && column > 0:
+			b.WriteString("\n")
+			column = 0
+		default:
+			b.WriteString(" ")
+			column++
+		}
+		b.WriteString(word)
+		column += cols
+	}
+	return b.String()
+}
@@ -0,0 +1,15 @@
+package prompter
+
+import (
+	"testing"
+
+	"github.com/matthewmueller/diff"
+)
+
+func TestWrapText(t *testing.T) {
+	diff.TestString(t, wrapText("This will delete every file in the bucket. Continue?", 20), "This will delete\nevery file in the\nbucket. Continue?")
+	diff.TestString(t, wrapText("Keep\nlines as they are", 10), "Keep\nlines as\nthey are")
+	diff.TestString(t, wrapText("A supercalifragilistic word", 10), "A\nsupercalifragilistic\nword")
+	diff.TestString(t, wrapText("日本語の 質問です", 10), "日本語の\n質問です")
+	diff.TestString(t, wrapText("Not wrapped", 0), "Not wrapped")
+}